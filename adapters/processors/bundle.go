@@ -0,0 +1,185 @@
+package processors
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha1" //nolint:gosec // used only to make unique file names
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gabriel-vasile/mimetype"
+	"go.uber.org/zap"
+	"golang.org/x/net/html"
+
+	"github.com/derfenix/webarchive/adapters/processors/internal"
+	"github.com/derfenix/webarchive/config"
+	"github.com/derfenix/webarchive/entity"
+)
+
+const bundleResourcesDir = "resources"
+
+func NewHTMLBundle(cfg config.Inline, client *http.Client, log *zap.Logger) *HTMLBundle {
+	return &HTMLBundle{cfg: cfg, client: client, log: log}
+}
+
+// HTMLBundle saves the page as zip archive with index.html and all page resources stored as separate files.
+type HTMLBundle struct {
+	cfg    config.Inline
+	client *http.Client
+	log    *zap.Logger
+}
+
+func (b *HTMLBundle) Process(ctx context.Context, page *entity.PageBase, cache *entity.Cache) ([]entity.File, error) {
+	reader, err := pageDocument(ctx, b.client, page, cache)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := newBundleResources()
+
+	getter := func(ctx context.Context, url string) (*http.Response, error) {
+		return cachedGet(ctx, b.client, cache, url)
+	}
+
+	document, err := internal.NewMediaInline(b.log, getter).
+		WithConcurrency(b.cfg.Concurrency).
+		WithFrameDepth(b.cfg.FrameDepth).
+		WithEncoder(resources.add).
+		WithStylesheetReference(resources.stylesheetReference).
+		Inline(ctx, reader, page.URL)
+	if err != nil {
+		return nil, fmt.Errorf("inline media: %w", err)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	archive := zip.NewWriter(buf)
+
+	indexWriter, err := archive.Create("index.html")
+	if err != nil {
+		return nil, fmt.Errorf("create index.html: %w", err)
+	}
+
+	if err := html.Render(indexWriter, document); err != nil {
+		return nil, fmt.Errorf("render result html: %w", err)
+	}
+
+	if err := resources.write(archive); err != nil {
+		return nil, fmt.Errorf("write resources: %w", err)
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, fmt.Errorf("close zip: %w", err)
+	}
+
+	return []entity.File{entity.NewFile("page.zip", buf.Bytes())}, nil
+}
+
+type bundleResources struct {
+	mu    sync.Mutex
+	paths map[string]string
+	files map[string][]byte
+}
+
+func newBundleResources() *bundleResources {
+	return &bundleResources{
+		paths: make(map[string]string),
+		files: make(map[string][]byte),
+	}
+}
+
+// add stores resource data and returns its path relative to the index.html.
+func (r *bundleResources) add(resourceURL string, mime string, data []byte) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if filePath, ok := r.paths[resourceURL]; ok {
+		return filePath, nil
+	}
+
+	filePath, err := resourcePath(bundleResourcesDir, resourceURL, mime)
+	if err != nil {
+		return "", err
+	}
+
+	r.paths[resourceURL] = filePath
+	r.files[filePath] = data
+
+	return filePath, nil
+}
+
+// stylesheetReference converts the path relative to the index.html to the path relative to the stylesheet,
+// which is stored in the host directory, see resourcePath.
+func (r *bundleResources) stylesheetReference(reference string, _ string) string {
+	if !strings.HasPrefix(reference, bundleResourcesDir+"/") {
+		return reference
+	}
+
+	return "../" + strings.TrimPrefix(reference, bundleResourcesDir+"/")
+}
+
+func (r *bundleResources) write(archive *zip.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	filePaths := make([]string, 0, len(r.files))
+	for filePath := range r.files {
+		filePaths = append(filePaths, filePath)
+	}
+
+	sort.Strings(filePaths)
+
+	for _, filePath := range filePaths {
+		writer, err := archive.Create(filePath)
+		if err != nil {
+			return fmt.Errorf("create %s: %w", filePath, err)
+		}
+
+		if _, err := writer.Write(r.files[filePath]); err != nil {
+			return fmt.Errorf("write %s: %w", filePath, err)
+		}
+	}
+
+	return nil
+}
+
+// resourcePath makes a path like dir/example.com/style-0a1b2c3d.css. The hash of the full URL
+// keeps paths unique for the same file names in different directories or with different queries.
+func resourcePath(dir string, resourceURL string, mime string) (string, error) {
+	parsedURL, err := url.Parse(resourceURL)
+	if err != nil {
+		return "", fmt.Errorf("parse resource url: %w", err)
+	}
+
+	sum := sha1.Sum([]byte(resourceURL)) //nolint:gosec // see import comment
+	hash := hex.EncodeToString(sum[:4])
+
+	name := path.Base(parsedURL.Path)
+	ext := path.Ext(name)
+	name = strings.TrimSuffix(name, ext)
+
+	if ext == "" {
+		mime, _, _ = strings.Cut(mime, ";")
+		if detected := mimetype.Lookup(strings.TrimSpace(mime)); detected != nil {
+			ext = detected.Extension()
+		}
+	}
+
+	switch name {
+	case "", ".", "/":
+		name = "index"
+	}
+
+	host := parsedURL.Hostname()
+	if host == "" {
+		host = "local"
+	}
+
+	return path.Join(dir, host, name+"-"+hash+ext), nil
+}
@@ -0,0 +1,86 @@
+package processors
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/derfenix/webarchive/config"
+	"github.com/derfenix/webarchive/entity"
+)
+
+func TestHTMLBundle_Process(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/page", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(`<html><head><link rel="stylesheet" href="/static/style.css"></head>` +
+			`<body><img src="/static/image.png"><img src="static/image.png"></body></html>`))
+	})
+	mux.HandleFunc("/static/style.css", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/css")
+		_, _ = w.Write([]byte("body { background: url(image.png); }"))
+	})
+	mux.HandleFunc("/static/image.png", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write([]byte("not really a png"))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	page := &entity.PageBase{URL: server.URL + "/page"}
+
+	files, err := NewHTMLBundle(config.Inline{Concurrency: 4, FrameDepth: 2}, server.Client(), zaptest.NewLogger(t)).Process(context.Background(), page, entity.NewCache())
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "application/zip", files[0].MimeType)
+
+	archive, err := zip.NewReader(bytes.NewReader(files[0].Data), int64(len(files[0].Data)))
+	require.NoError(t, err)
+
+	contents := make(map[string]string)
+
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		require.NoError(t, err)
+
+		data, err := io.ReadAll(reader)
+		require.NoError(t, err)
+
+		contents[file.Name] = string(data)
+	}
+
+	require.Len(t, contents, 3)
+
+	index := contents["index.html"]
+
+	var stylesheet, image string
+
+	for name, data := range contents {
+		switch {
+		case name == "index.html":
+			continue
+		case strings.HasSuffix(name, ".css"):
+			stylesheet = data
+		default:
+			image = name
+			assert.Equal(t, "not really a png", data)
+		}
+
+		assert.Contains(t, index, `"`+name+`"`)
+	}
+
+	// Stylesheet is stored in the host directory, so the image path is relative to it.
+	assert.Equal(t, `body { background: url("../`+strings.TrimPrefix(image, "resources/")+`"); }`, stylesheet)
+}
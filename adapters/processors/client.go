@@ -0,0 +1,334 @@
+package processors
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"maps"
+	"net"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/net/publicsuffix"
+
+	"github.com/derfenix/webarchive/config"
+)
+
+// NewHTTPClient makes the client shared by all formats.
+func NewHTTPClient(cfg config.Client, politeness config.Politeness, egress config.Egress) (*http.Client, error) {
+	jar, err := cookiejar.New(&cookiejar.Options{
+		PublicSuffixList: publicsuffix.List,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create cookie jar: %w", err)
+	}
+
+	proxy := http.ProxyFromEnvironment
+
+	if cfg.Proxy != "" {
+		proxyURL, err := parseProxy(cfg.Proxy)
+		if err != nil {
+			return nil, err
+		}
+
+		proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig, err := newTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := newEgressPolicy(egress)
+	if err != nil {
+		return nil, fmt.Errorf("new egress policy: %w", err)
+	}
+
+	transport := &http.Transport{
+		Proxy: proxy,
+		DialContext: policy.dialContext(&net.Dialer{
+			Timeout:   cfg.DialTimeout,
+			KeepAlive: time.Second * 10,
+		}),
+		TLSClientConfig:        tlsConfig,
+		MaxIdleConns:           20,
+		MaxIdleConnsPerHost:    5,
+		MaxConnsPerHost:        10,
+		IdleConnTimeout:        time.Second * 60,
+		ResponseHeaderTimeout:  cfg.ResponseHeaderTimeout,
+		MaxResponseHeaderBytes: 1024 * 1024 * 50,
+		WriteBufferSize:        256,
+		ReadBufferSize:         1024 * 64,
+		ForceAttemptHTTP2:      true,
+	}
+
+	maxRedirects := cfg.MaxRedirects
+	headers := clientHeaders(cfg)
+
+	return &http.Client{
+		Transport: &headersTransport{
+			base: newPoliteTransport(
+				&egressTransport{base: transport, policy: policy, proxy: proxy},
+				politeness,
+				headers,
+			),
+			headers: headers,
+		},
+		CheckRedirect: func(_ *http.Request, via []*http.Request) error {
+			if len(via) > maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+
+			return nil
+		},
+		Jar:     jar,
+		Timeout: cfg.Timeout,
+	}, nil
+}
+
+func parseProxy(value string) (*url.URL, error) {
+	proxyURL, err := url.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("parse proxy url: %w", err)
+	}
+
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q", proxyURL.Scheme)
+	}
+
+	if proxyURL.Host == "" {
+		return nil, fmt.Errorf("proxy url %s has no host", value)
+	}
+
+	return proxyURL, nil
+}
+
+func newTLSConfig(cfg config.Client) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.TLSInsecure, //nolint:gosec // explicitly enabled in config for internal hosts
+	}
+
+	if cfg.TLSCAFile == "" {
+		return tlsConfig, nil
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	certificates, err := os.ReadFile(cfg.TLSCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("read ca file: %w", err)
+	}
+
+	if !pool.AppendCertsFromPEM(certificates) {
+		return nil, fmt.Errorf("no certificates found in ca file %s", cfg.TLSCAFile)
+	}
+
+	tlsConfig.RootCAs = pool
+
+	return tlsConfig, nil
+}
+
+// clientHeaders returns the configured headers added to all requests.
+func clientHeaders(cfg config.Client) http.Header {
+	headers := make(http.Header, len(cfg.Headers)+2)
+
+	for name, value := range cfg.Headers {
+		headers.Set(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+
+	if cfg.UserAgent != "" {
+		headers.Set("User-Agent", cfg.UserAgent)
+	}
+
+	if cfg.AcceptLanguage != "" {
+		headers.Set("Accept-Language", cfg.AcceptLanguage)
+	}
+
+	return headers
+}
+
+// headersTransport adds the configured headers and the matching profile credentials to the requests,
+// the headers set by processors are kept.
+type headersTransport struct {
+	base    http.RoundTripper
+	headers http.Header
+}
+
+func (t *headersTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+
+	for name, values := range t.headers {
+		if req.Header.Get(name) == "" {
+			req.Header[name] = values
+		}
+	}
+
+	profile := profileFor(req.Context(), req.URL.Hostname())
+	if profile == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	// The response keeps the request without the profile credentials, it is recorded by WARC.
+	recorded := req.Clone(req.Context())
+
+	applyProfile(req, profile)
+
+	response, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	response.Request = recorded
+
+	return response, nil
+}
+
+// wkhtmlOptions are the client settings passed to wkhtmltopdf and wkhtmltoimage. The headers and cookies
+// may hold the credentials, so they are not passed in the command line, see runWkhtml.
+type wkhtmlOptions struct {
+	proxy string
+	// remoteLookup makes the proxy resolve the hostnames.
+	remoteLookup bool
+	headers      http.Header
+	// propagateHeaders sends the headers with the resource requests too. The profile headers and credentials
+	// are sent with the page request only, so they are not leaked to other hosts.
+	propagateHeaders bool
+	// cookies are written to the cookie jar, it sends them to the cookie domain only.
+	cookies []*http.Cookie
+	// disableLocalFiles denies the file:// resources of the page.
+	disableLocalFiles bool
+}
+
+// newWkhtmlOptions returns the options for the page. With the egress policy enabled all requests are sent through
+// the egress proxy, which checks them after DNS resolution and on every redirect.
+func newWkhtmlOptions(ctx context.Context, cfg config.Client, egress *EgressProxy, pageURL string) (wkhtmlOptions, error) {
+	opts := wkhtmlOptions{headers: clientHeaders(cfg)}
+	opts.proxy, opts.remoteLookup = wkhtmlProxy(cfg)
+	opts.propagateHeaders = len(opts.headers) > 0
+
+	parsedURL, err := url.Parse(pageURL)
+	if err != nil {
+		return wkhtmlOptions{}, fmt.Errorf("parse page url: %w", err)
+	}
+
+	if egress != nil && egress.policy != nil {
+		if err := checkScheme(parsedURL); err != nil {
+			return wkhtmlOptions{}, err
+		}
+
+		proxyURL, err := egress.URL()
+		if err != nil {
+			return wkhtmlOptions{}, fmt.Errorf("start egress proxy: %w", err)
+		}
+
+		opts.proxy, opts.remoteLookup = proxyURL, false
+		opts.disableLocalFiles = true
+	}
+
+	profile := profileFor(ctx, parsedURL.Hostname())
+	if profile == nil {
+		return opts, nil
+	}
+
+	for name, value := range profile.Headers {
+		opts.headers.Set(name, value)
+		opts.propagateHeaders = false
+	}
+
+	// wkhtmltopdf answers the authentication challenges of any host with --username and --password,
+	// the header is sent to the page host only.
+	if profile.Username != "" && opts.headers.Get("Authorization") == "" {
+		credentials := base64.StdEncoding.EncodeToString([]byte(profile.Username + ":" + profile.Password))
+		opts.headers.Set("Authorization", "Basic "+credentials)
+		opts.propagateHeaders = false
+	}
+
+	now := time.Now()
+
+	for i := range profile.Cookies {
+		if cookie := &profile.Cookies[i]; cookie.Matches(parsedURL, now) {
+			opts.cookies = append(opts.cookies, &http.Cookie{
+				Name:     cookie.Name,
+				Value:    cookie.Value,
+				Domain:   strings.TrimPrefix(cookie.Domain, "."),
+				Path:     cookie.Path,
+				Expires:  cookie.Expires,
+				Secure:   cookie.Secure,
+				HttpOnly: cookie.HTTPOnly,
+			})
+		}
+	}
+
+	return opts, nil
+}
+
+// wkhtmlProxy returns the proxy in wkhtmltopdf format and whether the hostnames are resolved by proxy.
+// wkhtmltopdf supports http and socks5 proxies only.
+func wkhtmlProxy(cfg config.Client) (string, bool) {
+	if cfg.Proxy == "" {
+		return "", false
+	}
+
+	proxyURL, err := parseProxy(cfg.Proxy)
+	if err != nil {
+		return "", false
+	}
+
+	remoteLookup := proxyURL.Scheme == "socks5h"
+
+	switch proxyURL.Scheme {
+	case "https":
+		proxyURL.Scheme = "http"
+	case "socks5h":
+		proxyURL.Scheme = "socks5"
+	}
+
+	return proxyURL.String(), remoteLookup
+}
+
+// args returns the command line arguments without the secrets.
+func (o wkhtmlOptions) args() []string {
+	var args []string
+
+	if o.proxy != "" {
+		args = append(args, "--proxy", o.proxy)
+
+		if o.remoteLookup {
+			args = append(args, "--proxy-hostname-lookup")
+		}
+	}
+
+	if o.propagateHeaders {
+		args = append(args, "--custom-header-propagation")
+	}
+
+	if o.disableLocalFiles {
+		args = append(args, "--disable-local-file-access")
+	}
+
+	return args
+}
+
+// headerArgs returns the headers arguments, they are read from stdin.
+func (o wkhtmlOptions) headerArgs() []string {
+	var args []string
+
+	for _, name := range slices.Sorted(maps.Keys(o.headers)) {
+		args = append(args, "--custom-header", name, o.headers.Get(name))
+	}
+
+	return args
+}
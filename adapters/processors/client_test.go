@@ -0,0 +1,147 @@
+package processors
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/derfenix/webarchive/config"
+	"github.com/derfenix/webarchive/entity"
+)
+
+func TestNewHTTPClient(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Client{
+		UserAgent:      "webarchive-test",
+		Headers:        map[string]string{" X-Token ": " secret "},
+		AcceptLanguage: "ru-RU,ru;q=0.9",
+		MaxRedirects:   1,
+		Timeout:        time.Second * 5,
+		TLSInsecure:    true,
+	}
+
+	t.Run("headers and tls", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "webarchive-test", r.UserAgent())
+			assert.Equal(t, "secret", r.Header.Get("X-Token"))
+			assert.Equal(t, "en", r.Header.Get("Accept-Language"), "request header is kept")
+		}))
+		t.Cleanup(server.Close)
+
+		client, err := NewHTTPClient(cfg, config.Politeness{}, config.Egress{})
+		require.NoError(t, err)
+
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+		req.Header.Set("Accept-Language", "en")
+
+		response, err := client.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, response.Body.Close())
+		assert.Empty(t, req.Header.Get("User-Agent"), "original request is not modified")
+	})
+
+	t.Run("redirects", func(t *testing.T) {
+		t.Parallel()
+
+		mux := http.NewServeMux()
+		mux.Handle("/1", http.RedirectHandler("/2", http.StatusFound))
+		mux.Handle("/2", http.RedirectHandler("/3", http.StatusFound))
+		mux.HandleFunc("/3", func(http.ResponseWriter, *http.Request) {})
+
+		server := httptest.NewServer(mux)
+		t.Cleanup(server.Close)
+
+		client, err := NewHTTPClient(cfg, config.Politeness{}, config.Egress{})
+		require.NoError(t, err)
+
+		response, err := client.Get(server.URL + "/2")
+		require.NoError(t, err)
+		require.NoError(t, response.Body.Close())
+
+		_, err = client.Get(server.URL + "/1")
+		assert.ErrorContains(t, err, "stopped after 1 redirects")
+	})
+
+	t.Run("invalid proxy", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewHTTPClient(config.Client{Proxy: "ftp://proxy:21"}, config.Politeness{}, config.Egress{})
+		assert.Error(t, err)
+	})
+}
+
+func TestWkhtmlOptions(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Client{
+		UserAgent:      "webarchive-test",
+		AcceptLanguage: "ru",
+		Proxy:          "socks5h://127.0.0.1:9050",
+	}
+
+	opts := wkhtmlOptionsFor(t, context.Background(), cfg)
+	assert.Equal(t, []string{
+		"--proxy", "socks5://127.0.0.1:9050",
+		"--proxy-hostname-lookup",
+		"--custom-header-propagation",
+	}, opts.args())
+	assert.Equal(t, []string{
+		"--custom-header", "Accept-Language", "ru",
+		"--custom-header", "User-Agent", "webarchive-test",
+	}, opts.headerArgs())
+
+	opts = wkhtmlOptionsFor(t, context.Background(), config.Client{})
+	assert.Empty(t, opts.args())
+	assert.Empty(t, opts.headerArgs())
+
+	profile := entity.NewProfile("intranet", "example.com")
+	profile.Headers = map[string]string{"X-Token": "secret"}
+	profile.Username = "user"
+	profile.Password = "pass"
+	profile.Cookies = []entity.Cookie{
+		{Name: "session", Value: "a b", Domain: ".example.com", Path: "/", IncludeSubdomains: true},
+		{Name: "other", Value: "c", Domain: "other.com", Path: "/"},
+	}
+
+	ctx := withProfiles(context.Background(), []*entity.Profile{profile})
+
+	opts = wkhtmlOptionsFor(t, ctx, config.Client{UserAgent: "webarchive-test"})
+	assert.Empty(t, opts.args(), "credentials are not passed in the command line")
+	assert.Equal(t, []string{
+		"--custom-header", "Authorization", "Basic dXNlcjpwYXNz",
+		"--custom-header", "User-Agent", "webarchive-test",
+		"--custom-header", "X-Token", "secret",
+	}, opts.headerArgs())
+
+	require.Len(t, opts.cookies, 1)
+	assert.Equal(t, `session="a b"; Path=/; Domain=example.com`, opts.cookies[0].String())
+}
+
+func TestWkhtmlArgsLine(t *testing.T) {
+	t.Parallel()
+
+	line, err := wkhtmlArgsLine([]string{"--custom-header", "X-Token", `a "quoted" \ value`, "-"})
+	require.NoError(t, err)
+	assert.Equal(t, `"--custom-header" "X-Token" "a \"quoted\" \\ value" "-"`+"\n", line)
+
+	_, err = wkhtmlArgsLine([]string{"--custom-header", "X-Token", "a\nb"})
+	assert.Error(t, err)
+}
+
+func wkhtmlOptionsFor(t *testing.T, ctx context.Context, cfg config.Client) wkhtmlOptions {
+	t.Helper()
+
+	opts, err := newWkhtmlOptions(ctx, cfg, nil, "https://example.com/")
+	require.NoError(t, err)
+
+	return opts
+}
@@ -0,0 +1,320 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+
+	"github.com/derfenix/webarchive/config"
+	"github.com/derfenix/webarchive/entity"
+)
+
+// defaultDeniedNetworks are the loopback, private, link-local, shared, reserved and multicast networks. They include
+// the cloud metadata addresses: 169.254.169.254, 100.100.100.200, 192.0.0.192 and fd00:ec2::254. NAT64 and 6to4
+// networks embed IPv4 addresses, which may be internal, so they are denied as a whole.
+var defaultDeniedNetworks = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("10.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("127.0.0.0/8"),
+	netip.MustParsePrefix("169.254.0.0/16"),
+	netip.MustParsePrefix("172.16.0.0/12"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.168.0.0/16"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("224.0.0.0/4"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("::/128"),
+	netip.MustParsePrefix("::1/128"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("64:ff9b:1::/48"),
+	netip.MustParsePrefix("2002::/16"),
+	netip.MustParsePrefix("fc00::/7"),
+	netip.MustParsePrefix("fe80::/10"),
+	netip.MustParsePrefix("ff00::/8"),
+}
+
+// egressPolicy checks the hosts and addresses the requests are sent to. The nil policy allows everything.
+type egressPolicy struct {
+	allowedNetworks []netip.Prefix
+	deniedNetworks  []netip.Prefix
+	allowedHosts    []string
+	deniedHosts     []string
+	resolver        *net.Resolver
+}
+
+func newEgressPolicy(cfg config.Egress) (*egressPolicy, error) {
+	if !cfg.Enabled {
+		return nil, nil //nolint:nilnil // nil policy allows everything
+	}
+
+	allowedNetworks, err := parseNetworks(cfg.AllowedNetworks)
+	if err != nil {
+		return nil, fmt.Errorf("parse allowed networks: %w", err)
+	}
+
+	deniedNetworks, err := parseNetworks(cfg.DeniedNetworks)
+	if err != nil {
+		return nil, fmt.Errorf("parse denied networks: %w", err)
+	}
+
+	return &egressPolicy{
+		allowedNetworks: allowedNetworks,
+		deniedNetworks:  deniedNetworks,
+		allowedHosts:    normalizeHosts(cfg.AllowedHosts),
+		deniedHosts:     normalizeHosts(cfg.DeniedHosts),
+		resolver:        net.DefaultResolver,
+	}, nil
+}
+
+func parseNetworks(values []string) ([]netip.Prefix, error) {
+	networks := make([]netip.Prefix, 0, len(values))
+
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		if addr, err := netip.ParseAddr(value); err == nil {
+			networks = append(networks, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+
+			continue
+		}
+
+		network, err := netip.ParsePrefix(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network %q: %w", value, err)
+		}
+
+		networks = append(networks, netip.PrefixFrom(network.Addr().Unmap(), network.Bits()).Masked())
+	}
+
+	return networks, nil
+}
+
+func normalizeHosts(values []string) []string {
+	hosts := make([]string, 0, len(values))
+
+	for _, value := range values {
+		if host := normalizeHost(value); host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+
+	return hosts
+}
+
+func normalizeHost(host string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), ".")
+}
+
+// matchHost reports whether the host matches one of the patterns, pattern like example.com matches the host
+// and its subdomains, *.example.com matches the subdomains only.
+func matchHost(patterns []string, host string) bool {
+	for _, pattern := range patterns {
+		if domain, ok := strings.CutPrefix(pattern, "*."); ok {
+			if strings.HasSuffix(host, "."+domain) {
+				return true
+			}
+
+			continue
+		}
+
+		if host == pattern || strings.HasSuffix(host, "."+pattern) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkHost checks the host lists, the allowed hosts are trusted and not checked by address.
+func (p *egressPolicy) checkHost(host string) (bool, error) {
+	if p == nil {
+		return true, nil
+	}
+
+	host = normalizeHost(host)
+
+	if matchHost(p.deniedHosts, host) {
+		return false, fmt.Errorf("host %s: %w", host, entity.ErrEgressDenied)
+	}
+
+	return matchHost(p.allowedHosts, host), nil
+}
+
+func (p *egressPolicy) checkAddr(addr netip.Addr) error {
+	addr = addr.Unmap().WithZone("")
+
+	for _, network := range p.deniedNetworks {
+		if network.Contains(addr) {
+			return fmt.Errorf("address %s: %w", addr, entity.ErrEgressDenied)
+		}
+	}
+
+	for _, network := range p.allowedNetworks {
+		if network.Contains(addr) {
+			return nil
+		}
+	}
+
+	for _, network := range defaultDeniedNetworks {
+		if network.Contains(addr) {
+			return fmt.Errorf("address %s: %w", addr, entity.ErrEgressDenied)
+		}
+	}
+
+	return nil
+}
+
+// resolve returns the host addresses, if all of them are allowed.
+func (p *egressPolicy) resolve(ctx context.Context, host string) ([]netip.Addr, error) {
+	if addr, err := netip.ParseAddr(strings.Trim(host, "[]")); err == nil {
+		if err := p.checkAddr(addr); err != nil {
+			return nil, err
+		}
+
+		return []netip.Addr{addr}, nil
+	}
+
+	addrs, err := p.resolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return nil, fmt.Errorf("resolve %s: %w", host, err)
+	}
+
+	for _, addr := range addrs {
+		if err := p.checkAddr(addr); err != nil {
+			return nil, fmt.Errorf("host %s: %w", host, err)
+		}
+	}
+
+	return addrs, nil
+}
+
+// checkURL checks the URL loaded by the external tools, only http and https URLs are allowed.
+func (p *egressPolicy) checkURL(ctx context.Context, rawURL string) error {
+	if p == nil {
+		return nil
+	}
+
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("parse url: %w", err)
+	}
+
+	if err := checkScheme(parsedURL); err != nil {
+		return err
+	}
+
+	trusted, err := p.checkHost(parsedURL.Hostname())
+	if err != nil || trusted {
+		return err
+	}
+
+	_, err = p.resolve(ctx, parsedURL.Hostname())
+
+	return err
+}
+
+// checkScheme allows the http and https URLs only, other schemes are not sent through the proxy.
+func checkScheme(parsedURL *url.URL) error {
+	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+		return fmt.Errorf("scheme %q: %w", parsedURL.Scheme, entity.ErrEgressDenied)
+	}
+
+	return nil
+}
+
+// dialContext connects to the checked addresses only, so the host can't be resolved to other address between
+// the check and the connection. The connections to the proxies are not checked, see egressTransport.
+func (p *egressPolicy) dialContext(dialer *net.Dialer) func(ctx context.Context, network, address string) (net.Conn, error) {
+	if p == nil {
+		return dialer.DialContext
+	}
+
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		if isProxied(ctx) {
+			return dialer.DialContext(ctx, network, address)
+		}
+
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, fmt.Errorf("split address: %w", err)
+		}
+
+		trusted, err := p.checkHost(host)
+		if err != nil {
+			return nil, err
+		}
+
+		if trusted {
+			return dialer.DialContext(ctx, network, address)
+		}
+
+		addrs, err := p.resolve(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+
+		var errs error
+
+		for _, addr := range addrs {
+			conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(addr.String(), port))
+			if err == nil {
+				return conn, nil
+			}
+
+			errs = errors.Join(errs, err)
+		}
+
+		return nil, errs
+	}
+}
+
+type proxiedKey struct{}
+
+func isProxied(ctx context.Context) bool {
+	proxied, _ := ctx.Value(proxiedKey{}).(bool)
+
+	return proxied
+}
+
+// egressTransport checks the request hosts. The requests sent through the proxy are checked by the host
+// addresses here, as the connection is made to the proxy address.
+type egressTransport struct {
+	base   http.RoundTripper
+	policy *egressPolicy
+	proxy  func(*http.Request) (*url.URL, error)
+}
+
+func (t *egressTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Hostname()
+
+	trusted, err := t.policy.checkHost(host)
+	if err != nil {
+		return nil, err
+	}
+
+	proxyURL, err := t.proxy(req)
+	if err != nil {
+		return nil, fmt.Errorf("get proxy: %w", err)
+	}
+
+	if proxyURL == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	if !trusted {
+		if _, err := t.policy.resolve(req.Context(), host); err != nil {
+			return nil, err
+		}
+	}
+
+	return t.base.RoundTrip(req.WithContext(context.WithValue(req.Context(), proxiedKey{}, true)))
+}
@@ -0,0 +1,386 @@
+package processors
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/proxy"
+
+	"github.com/derfenix/webarchive/config"
+	"github.com/derfenix/webarchive/entity"
+)
+
+// hopHeaders are the connection headers not forwarded by the proxy.
+var hopHeaders = []string{
+	"Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Proxy-Connection",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+// proxyEnvNames are the proxy environment variables replaced for the external commands.
+var proxyEnvNames = []string{"HTTP_PROXY", "HTTPS_PROXY", "ALL_PROXY", "NO_PROXY"}
+
+// NewEgressProxy makes the proxy applying the egress policy to wkhtmltopdf, wkhtmltoimage and external commands,
+// which resolve and connect to the page and resources hosts themselves. The proxy listens on the loopback address,
+// it is started by the first use. The requests are sent through the configured client proxy, if any.
+func NewEgressProxy(clientCfg config.Client, cfg config.Egress) (*EgressProxy, error) {
+	policy, err := newEgressPolicy(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("new egress policy: %w", err)
+	}
+
+	var upstream *url.URL
+
+	if clientCfg.Proxy != "" {
+		upstream, err = parseProxy(clientCfg.Proxy)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	dialer := &net.Dialer{Timeout: clientCfg.DialTimeout, KeepAlive: time.Second * 10}
+	proxy := func(*http.Request) (*url.URL, error) { return upstream, nil }
+
+	return &EgressProxy{
+		policy:   policy,
+		dialer:   dialer,
+		upstream: upstream,
+		timeout:  clientCfg.ResponseHeaderTimeout,
+		transport: &egressTransport{
+			base: &http.Transport{
+				Proxy:                 proxy,
+				DialContext:           policy.dialContext(dialer),
+				MaxIdleConnsPerHost:   5,
+				IdleConnTimeout:       time.Second * 60,
+				ResponseHeaderTimeout: clientCfg.ResponseHeaderTimeout,
+			},
+			policy: policy,
+			proxy:  proxy,
+		},
+	}, nil
+}
+
+// EgressProxy is the HTTP proxy connecting to the addresses allowed by the egress policy only. The nil proxy
+// and the proxy with disabled policy allow everything.
+type EgressProxy struct {
+	policy    *egressPolicy
+	dialer    *net.Dialer
+	upstream  *url.URL
+	timeout   time.Duration
+	transport http.RoundTripper
+
+	once sync.Once
+	url  string
+	err  error
+}
+
+// CheckURL checks the page URL before it is passed to the external tool.
+func (p *EgressProxy) CheckURL(ctx context.Context, rawURL string) error {
+	if p == nil {
+		return nil
+	}
+
+	return p.policy.checkURL(ctx, rawURL)
+}
+
+// URL returns the proxy URL, or empty string if the policy is disabled.
+func (p *EgressProxy) URL() (string, error) {
+	if p == nil || p.policy == nil {
+		return "", nil
+	}
+
+	p.once.Do(func() {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			p.err = fmt.Errorf("listen: %w", err)
+
+			return
+		}
+
+		server := &http.Server{
+			Handler:           p,
+			ReadHeaderTimeout: time.Second * 10,
+		}
+
+		go func() {
+			_ = server.Serve(listener)
+		}()
+
+		p.url = "http://" + listener.Addr().String()
+	})
+
+	return p.url, p.err
+}
+
+// proxyEnv returns the environment of the external commands with the proxy variables pointing to the proxy,
+// or nil to keep the process environment if the policy is disabled.
+func (p *EgressProxy) proxyEnv() ([]string, error) {
+	proxyURL, err := p.URL()
+	if err != nil {
+		return nil, fmt.Errorf("start egress proxy: %w", err)
+	}
+
+	if proxyURL == "" {
+		return nil, nil
+	}
+
+	env := slices.DeleteFunc(os.Environ(), func(variable string) bool {
+		name, _, _ := strings.Cut(variable, "=")
+
+		return slices.Contains(proxyEnvNames, strings.ToUpper(name))
+	})
+
+	for _, name := range []string{"HTTP_PROXY", "HTTPS_PROXY", "ALL_PROXY"} {
+		env = append(env, name+"="+proxyURL, strings.ToLower(name)+"="+proxyURL)
+	}
+
+	return env, nil
+}
+
+func (p *EgressProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodConnect {
+		p.connect(w, r)
+
+		return
+	}
+
+	if !r.URL.IsAbs() {
+		http.Error(w, "absolute url required", http.StatusBadRequest)
+
+		return
+	}
+
+	req := r.Clone(r.Context())
+	req.RequestURI = ""
+
+	for _, name := range hopHeaders {
+		req.Header.Del(name)
+	}
+
+	response, err := p.transport.RoundTrip(req)
+	if err != nil {
+		proxyError(w, err)
+
+		return
+	}
+
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	for _, name := range hopHeaders {
+		response.Header.Del(name)
+	}
+
+	for name, values := range response.Header {
+		w.Header()[name] = values
+	}
+
+	w.WriteHeader(response.StatusCode)
+
+	_, _ = io.Copy(w, response.Body)
+}
+
+// connect makes the tunnel for the https requests, the policy is checked by the target host.
+func (p *EgressProxy) connect(w http.ResponseWriter, r *http.Request) {
+	upstream, err := p.dialTunnel(r.Context(), r.Host)
+	if err != nil {
+		proxyError(w, err)
+
+		return
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		_ = upstream.Close()
+		http.Error(w, "hijacking not supported", http.StatusInternalServerError)
+
+		return
+	}
+
+	client, buffered, err := hijacker.Hijack()
+	if err != nil {
+		_ = upstream.Close()
+
+		return
+	}
+
+	if _, err := io.WriteString(client, "HTTP/1.1 200 Connection Established\r\n\r\n"); err != nil {
+		_ = upstream.Close()
+		_ = client.Close()
+
+		return
+	}
+
+	go func() {
+		_, _ = io.Copy(upstream, buffered)
+		_ = upstream.Close()
+	}()
+
+	_, _ = io.Copy(client, upstream)
+	_ = client.Close()
+}
+
+// dialTunnel connects to the address directly, or through the upstream proxy after the host addresses check.
+func (p *EgressProxy) dialTunnel(ctx context.Context, address string) (net.Conn, error) {
+	if p.upstream == nil {
+		return p.policy.dialContext(p.dialer)(ctx, "tcp", address)
+	}
+
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, fmt.Errorf("split address: %w", err)
+	}
+
+	trusted, err := p.policy.checkHost(host)
+	if err != nil {
+		return nil, err
+	}
+
+	if !trusted {
+		if _, err := p.policy.resolve(ctx, host); err != nil {
+			return nil, err
+		}
+	}
+
+	return dialUpstream(ctx, p.dialer, p.upstream, address, p.timeout)
+}
+
+// dialUpstream opens the tunnel to the address through the http, https or socks5 proxy.
+func dialUpstream(ctx context.Context, dialer *net.Dialer, upstream *url.URL, address string, timeout time.Duration) (net.Conn, error) {
+	var auth *proxy.Auth
+
+	if upstream.User != nil {
+		password, _ := upstream.User.Password()
+		auth = &proxy.Auth{User: upstream.User.Username(), Password: password}
+	}
+
+	proxyAddress := upstreamAddress(upstream)
+
+	if upstream.Scheme == "socks5" || upstream.Scheme == "socks5h" {
+		socks, err := proxy.SOCKS5("tcp", proxyAddress, auth, dialer)
+		if err != nil {
+			return nil, fmt.Errorf("new socks5 dialer: %w", err)
+		}
+
+		conn, err := socks.(proxy.ContextDialer).DialContext(ctx, "tcp", address)
+		if err != nil {
+			return nil, fmt.Errorf("dial through proxy: %w", err)
+		}
+
+		return conn, nil
+	}
+
+	conn, err := dialer.DialContext(ctx, "tcp", proxyAddress)
+	if err != nil {
+		return nil, fmt.Errorf("dial proxy: %w", err)
+	}
+
+	if timeout > 0 {
+		_ = conn.SetDeadline(time.Now().Add(timeout))
+	}
+
+	if upstream.Scheme == "https" {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: upstream.Hostname(), MinVersion: tls.VersionTLS12})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			_ = conn.Close()
+
+			return nil, fmt.Errorf("proxy tls handshake: %w", err)
+		}
+
+		conn = tlsConn
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: address},
+		Host:   address,
+		Header: http.Header{},
+	}
+
+	if auth != nil {
+		req.SetBasicAuth(auth.User, auth.Password)
+		req.Header.Set("Proxy-Authorization", req.Header.Get("Authorization"))
+		req.Header.Del("Authorization")
+	}
+
+	if err := req.Write(conn); err != nil {
+		_ = conn.Close()
+
+		return nil, fmt.Errorf("write connect request: %w", err)
+	}
+
+	reader := bufio.NewReader(conn)
+
+	response, err := http.ReadResponse(reader, req)
+	if err != nil {
+		_ = conn.Close()
+
+		return nil, fmt.Errorf("read connect response: %w", err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		_ = conn.Close()
+
+		return nil, fmt.Errorf("proxy connect: %w", &statusError{code: response.StatusCode})
+	}
+
+	_ = conn.SetDeadline(time.Time{})
+
+	return &bufferedConn{Conn: conn, reader: reader}, nil
+}
+
+// bufferedConn reads the tunnel data buffered while the CONNECT response was read.
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
+
+// upstreamAddress returns the proxy host with the scheme default port.
+func upstreamAddress(upstream *url.URL) string {
+	if upstream.Port() != "" {
+		return upstream.Host
+	}
+
+	port := "80"
+
+	switch upstream.Scheme {
+	case "https":
+		port = "443"
+	case "socks5", "socks5h":
+		port = "1080"
+	}
+
+	return net.JoinHostPort(upstream.Hostname(), port)
+}
+
+func proxyError(w http.ResponseWriter, err error) {
+	status := http.StatusBadGateway
+	if errors.Is(err, entity.ErrEgressDenied) {
+		status = http.StatusForbidden
+	}
+
+	http.Error(w, err.Error(), status)
+}
@@ -0,0 +1,305 @@
+package processors
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/derfenix/webarchive/config"
+	"github.com/derfenix/webarchive/entity"
+)
+
+func TestEgressPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy, err := newEgressPolicy(config.Egress{
+		Enabled:         true,
+		AllowedNetworks: []string{"10.1.0.0/16", "192.168.1.10"},
+		DeniedNetworks:  []string{"203.0.113.0/24", "10.1.2.0/24"},
+		AllowedHosts:    []string{"intranet.local"},
+		DeniedHosts:     []string{"*.example.com", "evil.test"},
+	})
+	require.NoError(t, err)
+
+	for addr, allowed := range map[string]bool{
+		"8.8.8.8":             true,
+		"2001:4860::8888":     true,
+		"127.0.0.1":           false,
+		"169.254.169.254":     false,
+		"100.100.100.200":     false,
+		"10.0.0.1":            false,
+		"::1":                 false,
+		"::ffff:127.0.0.1":    false,
+		"fd00:ec2::254":       false,
+		"fe80::1":             false,
+		"64:ff9b::7f00:1":     false,
+		"64:ff9b:1::a00:1":    false,
+		"2002:7f00:1::1":      false,
+		"2002:a9fe:a9fe::":    false,
+		"0.0.0.0":             false,
+		"10.1.1.1":            true,
+		"10.1.2.1":            false,
+		"192.168.1.10":        true,
+		"192.168.1.11":        false,
+		"203.0.113.5":         false,
+		"::ffff:192.168.1.10": true,
+	} {
+		err := policy.checkAddr(netip.MustParseAddr(addr))
+		if allowed {
+			assert.NoError(t, err, addr)
+		} else {
+			assert.ErrorIs(t, err, entity.ErrEgressDenied, addr)
+		}
+	}
+
+	trusted, err := policy.checkHost("Wiki.Intranet.Local.")
+	require.NoError(t, err)
+	assert.True(t, trusted)
+
+	trusted, err = policy.checkHost("example.com")
+	require.NoError(t, err)
+	assert.False(t, trusted)
+
+	_, err = policy.checkHost("www.example.com")
+	assert.ErrorIs(t, err, entity.ErrEgressDenied)
+
+	_, err = policy.checkHost("api.evil.test")
+	assert.ErrorIs(t, err, entity.ErrEgressDenied)
+
+	ctx := context.Background()
+
+	assert.ErrorIs(t, policy.checkURL(ctx, "file:///etc/passwd"), entity.ErrEgressDenied)
+	assert.ErrorIs(t, policy.checkURL(ctx, "http://169.254.169.254/latest/meta-data/"), entity.ErrEgressDenied)
+	assert.ErrorIs(t, policy.checkURL(ctx, "http://[::1]:8080/"), entity.ErrEgressDenied)
+	assert.NoError(t, policy.checkURL(ctx, "http://intranet.local/admin"))
+
+	_, err = newEgressPolicy(config.Egress{Enabled: true, DeniedNetworks: []string{"10.0.0.0/33"}})
+	assert.Error(t, err)
+
+	disabled, err := newEgressPolicy(config.Egress{})
+	require.NoError(t, err)
+	assert.NoError(t, disabled.checkURL(ctx, "http://127.0.0.1/"))
+}
+
+func TestNewHTTPClient_Egress(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "http://127.0.0.1"+strings.TrimPrefix(r.Host, "localhost")+"/admin", http.StatusFound)
+
+			return
+		}
+
+		_, _ = io.WriteString(w, "ok")
+	}))
+	t.Cleanup(server.Close)
+
+	localhostURL := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+	egress := config.Egress{Enabled: true, AllowedHosts: []string{"localhost"}}
+
+	t.Run("direct", func(t *testing.T) {
+		t.Parallel()
+
+		client, err := NewHTTPClient(config.Client{MaxRedirects: 10}, config.Politeness{}, egress)
+		require.NoError(t, err)
+
+		_, err = client.Get(server.URL)
+		assert.ErrorIs(t, err, entity.ErrEgressDenied)
+
+		response, err := client.Get(localhostURL)
+		require.NoError(t, err)
+		_ = response.Body.Close()
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		_, err = client.Get(localhostURL + "/redirect")
+		assert.ErrorIs(t, err, entity.ErrEgressDenied)
+	})
+
+	t.Run("proxy", func(t *testing.T) {
+		t.Parallel()
+
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Proxied", r.URL.String())
+		}))
+		t.Cleanup(proxy.Close)
+
+		client, err := NewHTTPClient(config.Client{MaxRedirects: 10, Proxy: proxy.URL}, config.Politeness{}, egress)
+		require.NoError(t, err)
+
+		_, err = client.Get("http://169.254.169.254/latest/meta-data/")
+		assert.ErrorIs(t, err, entity.ErrEgressDenied)
+
+		response, err := client.Get(localhostURL)
+		require.NoError(t, err)
+		_ = response.Body.Close()
+		assert.Equal(t, localhostURL+"/", response.Header.Get("X-Proxied"))
+	})
+}
+
+func TestEgressProxy(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, "secret")
+	}))
+	t.Cleanup(server.Close)
+
+	plainServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, "secret")
+	}))
+	t.Cleanup(plainServer.Close)
+
+	proxyClient := func(t *testing.T, egress *EgressProxy) *http.Client {
+		t.Helper()
+
+		proxyURL, err := egress.URL()
+		require.NoError(t, err)
+
+		parsedURL, err := url.Parse(proxyURL)
+		require.NoError(t, err)
+
+		return &http.Client{Transport: &http.Transport{
+			Proxy:           http.ProxyURL(parsedURL),
+			TLSClientConfig: &tls.Config{RootCAs: server.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs},
+		}}
+	}
+
+	t.Run("denied", func(t *testing.T) {
+		t.Parallel()
+
+		egress, err := NewEgressProxy(config.Client{}, config.Egress{Enabled: true})
+		require.NoError(t, err)
+
+		client := proxyClient(t, egress)
+
+		response, err := client.Get(plainServer.URL)
+		require.NoError(t, err)
+		_ = response.Body.Close()
+		assert.Equal(t, http.StatusForbidden, response.StatusCode)
+
+		_, err = client.Get(server.URL)
+		assert.Error(t, err)
+	})
+
+	t.Run("allowed", func(t *testing.T) {
+		t.Parallel()
+
+		egress, err := NewEgressProxy(config.Client{}, config.Egress{Enabled: true, AllowedNetworks: []string{"127.0.0.0/8"}})
+		require.NoError(t, err)
+
+		client := proxyClient(t, egress)
+
+		for _, serverURL := range []string{plainServer.URL, server.URL} {
+			response, err := client.Get(serverURL)
+			require.NoError(t, err)
+
+			body, err := io.ReadAll(response.Body)
+			_ = response.Body.Close()
+			require.NoError(t, err)
+			assert.Equal(t, "secret", string(body))
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		egress, err := NewEgressProxy(config.Client{}, config.Egress{})
+		require.NoError(t, err)
+
+		proxyURL, err := egress.URL()
+		require.NoError(t, err)
+		assert.Empty(t, proxyURL)
+
+		opts, err := newWkhtmlOptions(context.Background(), config.Client{}, egress, "http://127.0.0.1/")
+		require.NoError(t, err)
+		assert.Empty(t, opts.args())
+	})
+
+	t.Run("wkhtml options", func(t *testing.T) {
+		t.Parallel()
+
+		egress, err := NewEgressProxy(config.Client{}, config.Egress{Enabled: true})
+		require.NoError(t, err)
+
+		_, err = newWkhtmlOptions(context.Background(), config.Client{}, egress, "file:///etc/passwd")
+		assert.ErrorIs(t, err, entity.ErrEgressDenied)
+
+		proxyURL, err := egress.URL()
+		require.NoError(t, err)
+
+		opts, err := newWkhtmlOptions(context.Background(), config.Client{}, egress, "http://93.184.215.14/")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"--proxy", proxyURL, "--disable-local-file-access"}, opts.args())
+
+		// The configured proxy is used by the egress proxy, not by wkhtmltopdf.
+		withUpstream, err := NewEgressProxy(config.Client{Proxy: "socks5h://127.0.0.1:9050"}, config.Egress{Enabled: true})
+		require.NoError(t, err)
+
+		upstreamProxyURL, err := withUpstream.URL()
+		require.NoError(t, err)
+
+		opts, err = newWkhtmlOptions(context.Background(), config.Client{Proxy: "socks5h://127.0.0.1:9050"}, withUpstream, "http://93.184.215.14/")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"--proxy", upstreamProxyURL, "--disable-local-file-access"}, opts.args())
+	})
+
+	t.Run("upstream", func(t *testing.T) {
+		t.Parallel()
+
+		open, err := NewEgressProxy(config.Client{}, config.Egress{Enabled: true, AllowedNetworks: []string{"127.0.0.0/8"}})
+		require.NoError(t, err)
+
+		var proxied atomic.Int32
+
+		upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			proxied.Add(1)
+			open.ServeHTTP(w, r)
+		}))
+		t.Cleanup(upstream.Close)
+
+		denied, err := NewEgressProxy(config.Client{Proxy: upstream.URL}, config.Egress{Enabled: true})
+		require.NoError(t, err)
+
+		client := proxyClient(t, denied)
+
+		response, err := client.Get(plainServer.URL)
+		require.NoError(t, err)
+		_ = response.Body.Close()
+		assert.Equal(t, http.StatusForbidden, response.StatusCode)
+
+		_, err = client.Get(server.URL)
+		assert.Error(t, err)
+		assert.Zero(t, proxied.Load())
+
+		allowed, err := NewEgressProxy(
+			config.Client{Proxy: upstream.URL},
+			config.Egress{Enabled: true, AllowedNetworks: []string{"127.0.0.0/8"}},
+		)
+		require.NoError(t, err)
+
+		client = proxyClient(t, allowed)
+
+		for _, serverURL := range []string{plainServer.URL, server.URL} {
+			response, err := client.Get(serverURL)
+			require.NoError(t, err)
+
+			body, err := io.ReadAll(response.Body)
+			_ = response.Body.Close()
+			require.NoError(t, err)
+			assert.Equal(t, "secret", string(body))
+		}
+
+		assert.Equal(t, int32(2), proxied.Load())
+	})
+}
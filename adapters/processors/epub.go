@@ -0,0 +1,426 @@
+package processors
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/go-shiori/go-readability"
+	"go.uber.org/zap"
+	"golang.org/x/net/html"
+
+	"github.com/derfenix/webarchive/adapters/processors/internal"
+	"github.com/derfenix/webarchive/config"
+	"github.com/derfenix/webarchive/entity"
+)
+
+const (
+	epubImagesDir      = "images"
+	epubDefaultLang    = "en"
+	epubModifiedFormat = "2006-01-02T15:04:05Z"
+)
+
+func NewEPUB(cfg config.Inline, client *http.Client, log *zap.Logger) *EPUB {
+	return &EPUB{cfg: cfg, client: client, log: log}
+}
+
+// EPUB saves extracted article content with its images as EPUB 3 book.
+type EPUB struct {
+	cfg    config.Inline
+	client *http.Client
+	log    *zap.Logger
+}
+
+func (e *EPUB) Process(ctx context.Context, page *entity.PageBase, cache *entity.Cache) ([]entity.File, error) {
+	reader, err := pageDocument(ctx, e.client, page, cache)
+	if err != nil {
+		return nil, err
+	}
+
+	pageURL, err := url.Parse(page.URL)
+	if err != nil {
+		return nil, fmt.Errorf("parse page url: %w", err)
+	}
+
+	article, err := readability.FromReader(reader, pageURL)
+	if err != nil {
+		return nil, fmt.Errorf("extract article: %w", err)
+	}
+
+	images := &epubImages{paths: make(map[string]string)}
+
+	getter := func(ctx context.Context, url string) (*http.Response, error) {
+		return cachedGet(ctx, e.client, cache, url)
+	}
+
+	document, err := internal.NewMediaInline(e.log, getter).
+		WithConcurrency(e.cfg.Concurrency).
+		WithEncoder(images.add).
+		Inline(ctx, strings.NewReader(article.Content), page.URL)
+	if err != nil {
+		return nil, fmt.Errorf("inline images: %w", err)
+	}
+
+	book := epubBook{
+		ID:          page.ID.String(),
+		URL:         page.URL,
+		Title:       page.Meta.Title,
+		Description: page.Meta.Description,
+		Author:      article.Byline,
+		Language:    article.Language,
+		Modified:    page.Created.UTC().Format(epubModifiedFormat),
+		Images:      images.items,
+	}
+
+	if book.Title == "" {
+		book.Title = article.Title
+	}
+
+	if book.Description == "" {
+		book.Description = article.Excerpt
+	}
+
+	if book.Language == "" {
+		book.Language = epubDefaultLang
+	}
+
+	data, err := book.write(document)
+	if err != nil {
+		return nil, fmt.Errorf("write epub: %w", err)
+	}
+
+	return []entity.File{entity.NewFile("page.epub", data)}, nil
+}
+
+type epubImage struct {
+	ID       string
+	Path     string
+	MimeType string
+	Data     []byte
+}
+
+type epubImages struct {
+	mu    sync.Mutex
+	paths map[string]string
+	items []epubImage
+}
+
+func (i *epubImages) add(resourceURL string, mime string, data []byte) (string, error) {
+	mime, _, _ = strings.Cut(mime, ";")
+	mime = strings.TrimSpace(mime)
+
+	if !strings.HasPrefix(mime, "image/") {
+		return "", fmt.Errorf("unsupported resource type %s", mime)
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if filePath, ok := i.paths[resourceURL]; ok {
+		return filePath, nil
+	}
+
+	filePath, err := resourcePath(epubImagesDir, resourceURL, mime)
+	if err != nil {
+		return "", err
+	}
+
+	i.paths[resourceURL] = filePath
+	i.items = append(i.items, epubImage{
+		ID:       fmt.Sprintf("image%d", len(i.items)+1),
+		Path:     filePath,
+		MimeType: mime,
+		Data:     data,
+	})
+
+	return filePath, nil
+}
+
+type epubBook struct {
+	ID          string
+	URL         string
+	Title       string
+	Description string
+	Author      string
+	Language    string
+	Modified    string
+	Images      []epubImage
+}
+
+func (b *epubBook) write(document *html.Node) ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+	archive := zip.NewWriter(buf)
+
+	// The mimetype file must be the first one and must not be compressed.
+	mimetypeWriter, err := archive.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return nil, fmt.Errorf("create mimetype: %w", err)
+	}
+
+	if _, err := mimetypeWriter.Write([]byte("application/epub+zip")); err != nil {
+		return nil, fmt.Errorf("write mimetype: %w", err)
+	}
+
+	files := []struct {
+		name string
+		data []byte
+	}{
+		{name: "META-INF/container.xml", data: []byte(epubContainer)},
+		{name: "OEBPS/content.opf", data: b.packageDocument()},
+		{name: "OEBPS/nav.xhtml", data: b.navDocument()},
+		{name: "OEBPS/article.xhtml", data: b.articleDocument(document)},
+	}
+
+	for _, image := range b.Images {
+		files = append(files, struct {
+			name string
+			data []byte
+		}{name: "OEBPS/" + image.Path, data: image.Data})
+	}
+
+	for _, file := range files {
+		writer, err := archive.Create(file.name)
+		if err != nil {
+			return nil, fmt.Errorf("create %s: %w", file.name, err)
+		}
+
+		if _, err := writer.Write(file.data); err != nil {
+			return nil, fmt.Errorf("write %s: %w", file.name, err)
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, fmt.Errorf("close zip: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+const epubContainer = `<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>
+`
+
+func (b *epubBook) packageDocument() []byte {
+	buf := bytes.NewBuffer(nil)
+
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	buf.WriteString(`<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="uid">` + "\n")
+	buf.WriteString(`  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">` + "\n")
+	_, _ = fmt.Fprintf(buf, "    <dc:identifier id=\"uid\">urn:uuid:%s</dc:identifier>\n", xmlEscape(b.ID))
+	_, _ = fmt.Fprintf(buf, "    <dc:title>%s</dc:title>\n", xmlEscape(b.Title))
+	_, _ = fmt.Fprintf(buf, "    <dc:language>%s</dc:language>\n", xmlEscape(b.Language))
+	_, _ = fmt.Fprintf(buf, "    <dc:source>%s</dc:source>\n", xmlEscape(b.URL))
+
+	if b.Description != "" {
+		_, _ = fmt.Fprintf(buf, "    <dc:description>%s</dc:description>\n", xmlEscape(b.Description))
+	}
+
+	if b.Author != "" {
+		_, _ = fmt.Fprintf(buf, "    <dc:creator>%s</dc:creator>\n", xmlEscape(b.Author))
+	}
+
+	_, _ = fmt.Fprintf(buf, "    <meta property=\"dcterms:modified\">%s</meta>\n", b.Modified)
+	buf.WriteString("  </metadata>\n")
+
+	buf.WriteString("  <manifest>\n")
+	buf.WriteString(`    <item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>` + "\n")
+	buf.WriteString(`    <item id="article" href="article.xhtml" media-type="application/xhtml+xml"/>` + "\n")
+
+	for _, image := range b.Images {
+		_, _ = fmt.Fprintf(buf, "    <item id=\"%s\" href=\"%s\" media-type=\"%s\"/>\n",
+			image.ID, xmlEscape(image.Path), xmlEscape(image.MimeType))
+	}
+
+	buf.WriteString("  </manifest>\n")
+
+	buf.WriteString("  <spine>\n")
+	buf.WriteString(`    <itemref idref="article"/>` + "\n")
+	buf.WriteString("  </spine>\n")
+	buf.WriteString("</package>\n")
+
+	return buf.Bytes()
+}
+
+func (b *epubBook) navDocument() []byte {
+	buf := bytes.NewBuffer(nil)
+
+	b.writeXHTMLHead(buf)
+	buf.WriteString(`<nav epub:type="toc" id="toc"><ol>`)
+	_, _ = fmt.Fprintf(buf, `<li><a href="article.xhtml">%s</a></li>`, xmlEscape(b.Title))
+	buf.WriteString("</ol></nav>\n</body>\n</html>\n")
+
+	return buf.Bytes()
+}
+
+func (b *epubBook) articleDocument(document *html.Node) []byte {
+	buf := bytes.NewBuffer(nil)
+
+	b.writeXHTMLHead(buf)
+	_, _ = fmt.Fprintf(buf, "<h1>%s</h1>\n", xmlEscape(b.Title))
+
+	body := findElement(document, "body")
+	if body == nil {
+		body = document
+	}
+
+	for c := body.FirstChild; c != nil; c = c.NextSibling {
+		writeXHTML(buf, c)
+	}
+
+	buf.WriteString("\n</body>\n</html>\n")
+
+	return buf.Bytes()
+}
+
+func (b *epubBook) writeXHTMLHead(buf *bytes.Buffer) {
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	buf.WriteString("<!DOCTYPE html>\n")
+	_, _ = fmt.Fprintf(buf,
+		"<html xmlns=\"http://www.w3.org/1999/xhtml\" xmlns:epub=\"http://www.idpf.org/2007/ops\" xml:lang=\"%[1]s\" lang=\"%[1]s\">\n",
+		xmlEscape(b.Language),
+	)
+	_, _ = fmt.Fprintf(buf, "<head><meta charset=\"utf-8\"/><title>%s</title></head>\n<body>\n", xmlEscape(b.Title))
+}
+
+var (
+	xhtmlSkipElements = map[string]struct{}{
+		"script": {}, "style": {}, "noscript": {}, "template": {}, "iframe": {},
+		"object": {}, "embed": {}, "form": {}, "input": {}, "button": {}, "select": {}, "textarea": {},
+	}
+	xhtmlVoidElements = map[string]struct{}{
+		"area": {}, "br": {}, "col": {}, "hr": {}, "img": {}, "source": {}, "track": {}, "wbr": {},
+	}
+	// Attributes referring to remote resources or scripts, which are not allowed in the book.
+	xhtmlSkipAttributes = map[string]struct{}{
+		"srcset": {}, "sizes": {}, "style": {}, "loading": {}, "decoding": {},
+	}
+	// Elements dropped if their src is not embedded into the book, like failed or not image resources.
+	xhtmlResourceElements = map[string]struct{}{
+		"img": {}, "audio": {}, "video": {}, "source": {}, "track": {},
+	}
+)
+
+// writeXHTML serializes HTML node as XHTML, dropping the elements and attributes not allowed in EPUB content.
+func writeXHTML(buf *bytes.Buffer, n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		buf.WriteString(xmlEscape(n.Data))
+
+	case html.ElementNode:
+		if _, skip := xhtmlSkipElements[n.Data]; skip || !isXMLName(n.Data) {
+			return
+		}
+
+		if _, resource := xhtmlResourceElements[n.Data]; resource {
+			if src, ok := attrValue(n, "src"); ok && !isLocalRef(src) {
+				return
+			}
+		}
+
+		buf.WriteString("<" + n.Data)
+
+		for _, attr := range n.Attr {
+			if _, skip := xhtmlSkipAttributes[attr.Key]; skip {
+				continue
+			}
+
+			if attr.Namespace != "" || strings.HasPrefix(attr.Key, "on") || !isXMLName(attr.Key) {
+				continue
+			}
+
+			if attr.Key == "poster" && !isLocalRef(attr.Val) {
+				continue
+			}
+
+			_, _ = fmt.Fprintf(buf, " %s=\"%s\"", attr.Key, xmlEscape(attr.Val))
+		}
+
+		if _, void := xhtmlVoidElements[n.Data]; void {
+			buf.WriteString("/>")
+
+			return
+		}
+
+		buf.WriteString(">")
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			writeXHTML(buf, c)
+		}
+
+		buf.WriteString("</" + n.Data + ">")
+
+	case html.DocumentNode:
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			writeXHTML(buf, c)
+		}
+	}
+}
+
+func attrValue(n *html.Node, key string) (string, bool) {
+	for _, attr := range n.Attr {
+		if attr.Namespace == "" && attr.Key == key {
+			return attr.Val, true
+		}
+	}
+
+	return "", false
+}
+
+// isLocalRef reports whether the reference points to the book file, the embedded resources are referred
+// by the relative paths.
+func isLocalRef(ref string) bool {
+	parsed, err := url.Parse(strings.TrimSpace(ref))
+	if err != nil {
+		return false
+	}
+
+	return parsed.Scheme == "" && parsed.Host == "" && parsed.Path != ""
+}
+
+func isXMLName(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for i, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_':
+		case i > 0 && (r >= '0' && r <= '9' || r == '-' || r == '.'):
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+func xmlEscape(s string) string {
+	buf := bytes.NewBuffer(nil)
+	_ = xml.EscapeText(buf, []byte(s))
+
+	return buf.String()
+}
+
+func findElement(n *html.Node, name string) *html.Node {
+	if n.Type == html.ElementNode && n.Data == name {
+		return n
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if found := findElement(c, name); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package processors
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/derfenix/webarchive/config"
+	"github.com/derfenix/webarchive/entity"
+)
+
+func TestEPUB_Process(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/image.png", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write([]byte("not really a png"))
+	})
+	mux.HandleFunc("/notes.txt", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("notes"))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	cache := entity.NewCache()
+	_, err := cache.Write([]byte(strings.Replace(articleHTML, "<h1>Article title</h1>", `<h1>Article title</h1><p><img src="/image.png" alt="image"><br>Image &amp; caption</p>`+
+		`<p>Not embedded <img src="/missing.png" alt="missing"><img src="/notes.txt" alt="notes"></p>`, 1)))
+	require.NoError(t, err)
+
+	page := &entity.PageBase{
+		ID:      uuid.New(),
+		URL:     server.URL + "/article",
+		Created: time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC),
+		Meta:    entity.Meta{Title: "Article & title", Description: "Description"},
+	}
+
+	files, err := NewEPUB(config.Inline{Concurrency: 4, FrameDepth: 2}, server.Client(), zaptest.NewLogger(t)).Process(context.Background(), page, cache)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "application/epub+zip", files[0].MimeType)
+
+	archive, err := zip.NewReader(bytes.NewReader(files[0].Data), int64(len(files[0].Data)))
+	require.NoError(t, err)
+	require.NotEmpty(t, archive.File)
+	assert.Equal(t, "mimetype", archive.File[0].Name)
+	assert.Equal(t, zip.Store, archive.File[0].Method)
+
+	contents := make(map[string]string)
+
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		require.NoError(t, err)
+
+		data, err := io.ReadAll(reader)
+		require.NoError(t, err)
+
+		contents[file.Name] = string(data)
+	}
+
+	for name, data := range contents {
+		if strings.HasSuffix(name, ".xhtml") || strings.HasSuffix(name, ".opf") || strings.HasSuffix(name, ".xml") {
+			decoder := xml.NewDecoder(strings.NewReader(data))
+			for {
+				_, err := decoder.Token()
+				if err == io.EOF {
+					break
+				}
+
+				require.NoError(t, err, name)
+			}
+		}
+	}
+
+	opf := contents["OEBPS/content.opf"]
+	assert.Contains(t, opf, "<dc:title>Article &amp; title</dc:title>")
+	assert.Contains(t, opf, "<dc:description>Description</dc:description>")
+	assert.Contains(t, opf, "urn:uuid:"+page.ID.String())
+	assert.Contains(t, opf, `<meta property="dcterms:modified">2023-04-05T06:07:08Z</meta>`)
+
+	article := contents["OEBPS/article.xhtml"]
+	assert.Contains(t, article, "Third paragraph of the article.")
+	assert.Contains(t, article, "<br/>")
+	assert.NotContains(t, article, "Copyright footer")
+
+	var imagePath string
+
+	for name := range contents {
+		if strings.HasPrefix(name, "OEBPS/images/") {
+			imagePath = strings.TrimPrefix(name, "OEBPS/")
+		}
+	}
+
+	// The resources not embedded into the book are not referred from it.
+	assert.NotContains(t, article, `src="`+server.URL)
+	assert.Contains(t, article, "Not embedded")
+
+	require.NotEmpty(t, imagePath)
+	assert.Contains(t, article, `src="`+imagePath+`"`)
+	assert.Contains(t, opf, `href="`+imagePath+`" media-type="image/png"`)
+}
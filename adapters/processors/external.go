@@ -0,0 +1,86 @@
+package processors
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"strings"
+
+	"github.com/derfenix/webarchive/config"
+	"github.com/derfenix/webarchive/entity"
+)
+
+const externalURLPlaceholder = "{url}"
+
+func NewExternal(cfg config.ExternalFormat, client *http.Client, egress *EgressProxy) *External {
+	return &External{cfg: cfg, client: client, egress: egress}
+}
+
+// External makes the format declared in config by running external command. The command requests are sent through
+// the egress proxy, if the command respects the proxy environment variables.
+type External struct {
+	cfg    config.ExternalFormat
+	client *http.Client
+	egress *EgressProxy
+}
+
+func (e *External) Process(ctx context.Context, page *entity.PageBase, cache *entity.Cache) ([]entity.File, error) {
+	if err := e.egress.CheckURL(ctx, page.URL); err != nil {
+		return nil, err
+	}
+
+	env, err := e.egress.proxyEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, len(e.cfg.Command)-1)
+	for i, arg := range e.cfg.Command[1:] {
+		args[i] = strings.ReplaceAll(arg, externalURLPlaceholder, page.URL)
+	}
+
+	stdout := bytes.NewBuffer(nil)
+	stderr := bytes.NewBuffer(nil)
+
+	//nolint:gosec // command is set by the service operator
+	cmd := exec.CommandContext(ctx, e.cfg.Command[0], args...)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.Env = env
+
+	if e.cfg.Input == config.ExternalInputHTML {
+		reader := cache.Reader()
+
+		if reader == nil {
+			response, err := get(ctx, e.client, page.URL)
+			if err != nil {
+				return nil, err
+			}
+
+			defer func() {
+				_ = response.Body.Close()
+			}()
+
+			reader = response.Body
+		}
+
+		cmd.Stdin = reader
+	}
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("run %s: %w: %s", e.cfg.Command[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	if stdout.Len() == 0 {
+		return nil, fmt.Errorf("empty %s output", e.cfg.Command[0])
+	}
+
+	file := entity.NewFile(e.cfg.Filename, stdout.Bytes())
+	if e.cfg.MimeType != "" {
+		file.MimeType = e.cfg.MimeType
+	}
+
+	return []entity.File{file}, nil
+}
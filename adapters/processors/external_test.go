@@ -0,0 +1,91 @@
+package processors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/derfenix/webarchive/config"
+	"github.com/derfenix/webarchive/entity"
+)
+
+func TestExternal_Process(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	page := &entity.PageBase{URL: "https://example.com/page"}
+
+	cache := entity.NewCache()
+	_, err := cache.Write([]byte("<html><body>Hello</body></html>"))
+	require.NoError(t, err)
+
+	t.Run("url", func(t *testing.T) {
+		t.Parallel()
+
+		files, err := NewExternal(config.ExternalFormat{
+			Name:     "echo",
+			Command:  []string{"echo", "-n", "url is {url}"},
+			Input:    config.ExternalInputURL,
+			Filename: "url.txt",
+		}, nil, nil).Process(ctx, page, cache)
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+
+		assert.Equal(t, "url.txt", files[0].Name)
+		assert.Equal(t, "url is https://example.com/page", string(files[0].Data))
+	})
+
+	t.Run("html", func(t *testing.T) {
+		t.Parallel()
+
+		files, err := NewExternal(config.ExternalFormat{
+			Name:     "cat",
+			Command:  []string{"cat"},
+			Input:    config.ExternalInputHTML,
+			Filename: "page.html",
+			MimeType: "text/x-custom",
+		}, nil, nil).Process(ctx, page, cache)
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+
+		assert.Equal(t, "<html><body>Hello</body></html>", string(files[0].Data))
+		assert.Equal(t, "text/x-custom", files[0].MimeType)
+	})
+
+	t.Run("failed command", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewExternal(config.ExternalFormat{
+			Name:     "false",
+			Command:  []string{"false"},
+			Filename: "page.html",
+		}, nil, nil).Process(ctx, page, cache)
+		assert.Error(t, err)
+	})
+
+	t.Run("egress", func(t *testing.T) {
+		t.Parallel()
+
+		egress, err := NewEgressProxy(config.Client{}, config.Egress{Enabled: true})
+		require.NoError(t, err)
+
+		external := NewExternal(config.ExternalFormat{
+			Name:     "proxy",
+			Command:  []string{"sh", "-c", `printf %s "$HTTP_PROXY"`},
+			Filename: "proxy.txt",
+		}, nil, egress)
+
+		_, err = external.Process(ctx, &entity.PageBase{URL: "http://169.254.169.254/latest/meta-data/"}, cache)
+		assert.ErrorIs(t, err, entity.ErrEgressDenied)
+
+		files, err := external.Process(ctx, &entity.PageBase{URL: "http://93.184.215.14/"}, cache)
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+
+		proxyURL, err := egress.URL()
+		require.NoError(t, err)
+		assert.Equal(t, proxyURL, string(files[0].Data))
+	})
+}
@@ -3,47 +3,137 @@ package processors
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/derfenix/webarchive/config"
 	"github.com/derfenix/webarchive/entity"
 )
 
-func NewHeaders(client *http.Client) *Headers {
-	return &Headers{client: client}
+const (
+	headersFilename     = "headers"
+	headersJSONFilename = "headers.json"
+)
+
+func NewHeaders(cfg config.Headers, client *http.Client) (*Headers, error) {
+	cfg.Method = strings.ToUpper(cfg.Method)
+
+	if cfg.Method != http.MethodHead && cfg.Method != http.MethodGet {
+		return nil, fmt.Errorf("unsupported method %q, HEAD or GET expected", cfg.Method)
+	}
+
+	return &Headers{cfg: cfg, client: client}, nil
 }
 
+// Headers saves the final response headers as text and the full capture with all redirect hops
+// and TLS connection details as JSON.
 type Headers struct {
+	cfg    config.Headers
 	client *http.Client
 }
 
-func (h *Headers) Process(ctx context.Context, url string, _ *entity.Cache) ([]entity.File, error) {
-	var (
-		headersFile entity.File
-		err         error
-	)
+type headersCapture struct {
+	URL        string          `json:"url"`
+	Method     string          `json:"method"`
+	Redirects  []headersHop    `json:"redirects"`
+	FinalURL   string          `json:"final_url"`
+	StatusCode int             `json:"status_code"`
+	Protocol   string          `json:"protocol"`
+	Headers    http.Header     `json:"headers"`
+	TLS        *headersTLSInfo `json:"tls,omitempty"`
+	Captured   time.Time       `json:"captured"`
+}
 
-	req, reqErr := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+type headersHop struct {
+	URL        string          `json:"url"`
+	StatusCode int             `json:"status_code"`
+	Location   string          `json:"location"`
+	Headers    http.Header     `json:"headers"`
+	TLS        *headersTLSInfo `json:"tls,omitempty"`
+}
+
+type headersTLSInfo struct {
+	Version      string               `json:"version"`
+	CipherSuite  string               `json:"cipher_suite"`
+	ServerName   string               `json:"server_name"`
+	Protocol     string               `json:"negotiated_protocol,omitempty"`
+	Certificates []headersCertificate `json:"certificates"`
+}
+
+type headersCertificate struct {
+	Subject           string    `json:"subject"`
+	Issuer            string    `json:"issuer"`
+	SerialNumber      string    `json:"serial_number"`
+	NotBefore         time.Time `json:"not_before"`
+	NotAfter          time.Time `json:"not_after"`
+	DNSNames          []string  `json:"dns_names,omitempty"`
+	SHA256Fingerprint string    `json:"sha256_fingerprint"`
+}
+
+func (h *Headers) Process(ctx context.Context, page *entity.PageBase, _ *entity.Cache) ([]entity.File, error) {
+	capture := headersCapture{
+		URL:      page.URL,
+		Method:   h.cfg.Method,
+		Captured: time.Now().UTC(),
+	}
+
+	// Each redirect response is recorded by the client copy when it is followed. The client redirect limit
+	// is replaced by the headers one.
+	client := *h.client
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) > h.cfg.MaxRedirects {
+			return fmt.Errorf("stopped after %d redirects", h.cfg.MaxRedirects)
+		}
+
+		capture.Redirects = append(capture.Redirects, newHeadersHop(req.Response))
+
+		return nil
+	}
+
+	req, reqErr := http.NewRequestWithContext(ctx, h.cfg.Method, page.URL, nil)
 	if reqErr != nil {
 		return nil, fmt.Errorf("create request: %w", reqErr)
 	}
 
-	resp, doErr := h.client.Do(req)
+	resp, doErr := client.Do(req)
 	if doErr != nil {
 		return nil, fmt.Errorf("call url: %w", doErr)
 	}
 
+	// Body is not needed, closing it without reading for GET requests drops the connection.
 	if resp.Body != nil {
 		_ = resp.Body.Close()
 	}
 
-	headersFile, err = h.newFile(resp.Header)
+	capture.FinalURL = resp.Request.URL.String()
+	capture.StatusCode = resp.StatusCode
+	capture.Protocol = resp.Proto
+	capture.Headers = resp.Header
+	capture.TLS = newHeadersTLSInfo(resp.TLS)
 
+	if capture.Redirects == nil {
+		capture.Redirects = []headersHop{}
+	}
+
+	headersFile, err := h.newFile(resp.Header)
 	if err != nil {
 		return nil, fmt.Errorf("new file from headers: %w", err)
 	}
 
-	return []entity.File{headersFile}, nil
+	captureData, err := json.MarshalIndent(capture, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal headers capture: %w", err)
+	}
+
+	return []entity.File{headersFile, entity.NewFile(headersJSONFilename, captureData)}, nil
 }
 
 func (h *Headers) newFile(headers http.Header) (entity.File, error) {
@@ -53,5 +143,56 @@ func (h *Headers) newFile(headers http.Header) (entity.File, error) {
 		return entity.File{}, fmt.Errorf("write headers: %w", err)
 	}
 
-	return entity.NewFile("headers", buf.Bytes()), nil
+	return entity.NewFile(headersFilename, buf.Bytes()), nil
+}
+
+func newHeadersHop(response *http.Response) headersHop {
+	hop := headersHop{
+		URL:        response.Request.URL.String(),
+		StatusCode: response.StatusCode,
+		Headers:    response.Header,
+		TLS:        newHeadersTLSInfo(response.TLS),
+	}
+
+	if location, err := response.Location(); err == nil {
+		hop.Location = location.String()
+	} else if !errors.Is(err, http.ErrNoLocation) {
+		hop.Location = response.Header.Get("Location")
+	}
+
+	return hop
+}
+
+func newHeadersTLSInfo(state *tls.ConnectionState) *headersTLSInfo {
+	if state == nil {
+		return nil
+	}
+
+	info := headersTLSInfo{
+		Version:      tls.VersionName(state.Version),
+		CipherSuite:  tls.CipherSuiteName(state.CipherSuite),
+		ServerName:   state.ServerName,
+		Protocol:     state.NegotiatedProtocol,
+		Certificates: make([]headersCertificate, 0, len(state.PeerCertificates)),
+	}
+
+	for _, cert := range state.PeerCertificates {
+		info.Certificates = append(info.Certificates, newHeadersCertificate(cert))
+	}
+
+	return &info
+}
+
+func newHeadersCertificate(cert *x509.Certificate) headersCertificate {
+	fingerprint := sha256.Sum256(cert.Raw)
+
+	return headersCertificate{
+		Subject:           cert.Subject.String(),
+		Issuer:            cert.Issuer.String(),
+		SerialNumber:      cert.SerialNumber.String(),
+		NotBefore:         cert.NotBefore.UTC(),
+		NotAfter:          cert.NotAfter.UTC(),
+		DNSNames:          cert.DNSNames,
+		SHA256Fingerprint: hex.EncodeToString(fingerprint[:]),
+	}
 }
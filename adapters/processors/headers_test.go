@@ -0,0 +1,76 @@
+package processors
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/derfenix/webarchive/config"
+	"github.com/derfenix/webarchive/entity"
+)
+
+func TestHeaders_Process(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/page", http.StatusFound)
+	})
+	mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Method", r.Method)
+		_, _ = w.Write([]byte("page"))
+	})
+
+	server := httptest.NewTLSServer(mux)
+	t.Cleanup(server.Close)
+
+	// The client redirect policy is replaced by the headers one.
+	client := server.Client()
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	headers, err := NewHeaders(config.Headers{Method: "get", MaxRedirects: 10}, client)
+	require.NoError(t, err)
+
+	files, err := headers.Process(context.Background(), &entity.PageBase{URL: server.URL + "/old"}, entity.NewCache())
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	assert.Equal(t, "headers", files[0].Name)
+	assert.Contains(t, string(files[0].Data), "X-Method: GET")
+
+	assert.Equal(t, "headers.json", files[1].Name)
+
+	var capture headersCapture
+	require.NoError(t, json.Unmarshal(files[1].Data, &capture))
+
+	require.Len(t, capture.Redirects, 1)
+	assert.Equal(t, server.URL+"/old", capture.Redirects[0].URL)
+	assert.Equal(t, http.StatusFound, capture.Redirects[0].StatusCode)
+	assert.Equal(t, server.URL+"/page", capture.Redirects[0].Location)
+
+	assert.Equal(t, server.URL+"/page", capture.FinalURL)
+	assert.Equal(t, http.StatusOK, capture.StatusCode)
+	assert.Equal(t, "GET", capture.Headers.Get("X-Method"))
+
+	require.NotNil(t, capture.TLS)
+	assert.NotEmpty(t, capture.TLS.Version)
+	assert.NotEmpty(t, capture.TLS.CipherSuite)
+	require.NotEmpty(t, capture.TLS.Certificates)
+	assert.Len(t, capture.TLS.Certificates[0].SHA256Fingerprint, 64)
+
+	limited, err := NewHeaders(config.Headers{Method: http.MethodHead}, client)
+	require.NoError(t, err)
+
+	_, err = limited.Process(context.Background(), &entity.PageBase{URL: server.URL + "/old"}, entity.NewCache())
+	assert.ErrorContains(t, err, "stopped after 0 redirects")
+
+	_, err = NewHeaders(config.Headers{Method: http.MethodPost}, client)
+	assert.Error(t, err)
+}
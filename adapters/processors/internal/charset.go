@@ -0,0 +1,66 @@
+package internal
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/charset"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+const (
+	utf8Encoding = "utf-8"
+	// charsetPrescanSize is the size of the document start where the charset declaration is looked for.
+	charsetPrescanSize = 1024
+)
+
+// DecodeHTML returns the document transcoded to UTF-8 and the name of its source encoding. The encoding is
+// taken from the BOM, the contentType charset parameter or the <meta> declaration, in this order.
+// Documents without any declaration are treated as UTF-8.
+func DecodeHTML(reader io.Reader, contentType string) (io.Reader, string, error) {
+	buffered := bufio.NewReaderSize(reader, charsetPrescanSize)
+
+	prescan, err := buffered.Peek(charsetPrescanSize)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, "", err
+	}
+
+	encoding, name, certain := charset.DetermineEncoding(prescan, contentType)
+
+	// windows-1252 is the fallback of the detection, but undeclared documents are mostly UTF-8 nowadays.
+	if !certain && name == "windows-1252" && !bytes.Contains(bytes.ToLower(prescan), []byte("charset")) {
+		encoding, name = unicode.UTF8, utf8Encoding
+	}
+
+	decoder := unicode.BOMOverride(encoding.NewDecoder())
+
+	return transform.NewReader(buffered, decoder), name, nil
+}
+
+// SetUTF8Charset replaces the charset declarations of the decoded document, so it is rendered as UTF-8 one.
+func SetUTF8Charset(node *html.Node) {
+	for ; node != nil; node = node.NextSibling {
+		if node.Type == html.ElementNode && node.Data == "meta" {
+			setMetaCharset(node)
+		}
+
+		SetUTF8Charset(node.FirstChild)
+	}
+}
+
+func setMetaCharset(node *html.Node) {
+	httpEquiv, _ := attrValue(node.Attr, "http-equiv")
+
+	for idx, attr := range node.Attr {
+		switch {
+		case attr.Key == "charset":
+			node.Attr[idx].Val = utf8Encoding
+		case attr.Key == "content" && strings.EqualFold(strings.TrimSpace(httpEquiv), "content-type"):
+			node.Attr[idx].Val = "text/html; charset=" + utf8Encoding
+		}
+	}
+}
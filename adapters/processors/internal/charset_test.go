@@ -0,0 +1,104 @@
+package internal
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+)
+
+func TestDecodeHTML(t *testing.T) {
+	t.Parallel()
+
+	encode := func(t *testing.T, data string, encoder interface{ Bytes([]byte) ([]byte, error) }) []byte {
+		t.Helper()
+
+		encoded, err := encoder.Bytes([]byte(data))
+		require.NoError(t, err)
+
+		return encoded
+	}
+
+	tests := []struct {
+		name        string
+		data        []byte
+		contentType string
+		encoding    string
+		want        string
+	}{
+		{
+			name:        "header",
+			data:        encode(t, "<p>Привет</p>", charmap.Windows1251.NewEncoder()),
+			contentType: "text/html; charset=windows-1251",
+			encoding:    "windows-1251",
+			want:        "<p>Привет</p>",
+		},
+		{
+			name:     "meta charset",
+			data:     encode(t, `<meta charset="Shift_JIS"><p>こんにちは</p>`, japanese.ShiftJIS.NewEncoder()),
+			encoding: "shift_jis",
+			want:     `<meta charset="Shift_JIS"><p>こんにちは</p>`,
+		},
+		{
+			name:        "meta http-equiv",
+			data:        encode(t, `<meta http-equiv="Content-Type" content="text/html; charset=cp1251"><p>Привет</p>`, charmap.Windows1251.NewEncoder()),
+			contentType: "text/html",
+			encoding:    "windows-1251",
+			want:        `<meta http-equiv="Content-Type" content="text/html; charset=cp1251"><p>Привет</p>`,
+		},
+		{
+			name:        "bom",
+			data:        append([]byte("\xef\xbb\xbf"), "<p>Привет</p>"...),
+			contentType: "text/html; charset=windows-1251",
+			encoding:    "utf-8",
+			want:        "<p>Привет</p>",
+		},
+		{
+			name:     "undeclared",
+			data:     []byte("<p>Привет</p>"),
+			encoding: "utf-8",
+			want:     "<p>Привет</p>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			reader, encoding, err := DecodeHTML(bytes.NewReader(tt.data), tt.contentType)
+			require.NoError(t, err)
+
+			decoded, err := io.ReadAll(reader)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.encoding, encoding)
+			assert.Equal(t, tt.want, string(decoded))
+		})
+	}
+}
+
+func TestSetUTF8Charset(t *testing.T) {
+	t.Parallel()
+
+	document, err := html.Parse(strings.NewReader(`<html><head>
+<meta charset="windows-1251">
+<meta http-equiv="content-type" content="text/html; charset=windows-1251">
+<meta name="description" content="charset=windows-1251">
+</head></html>`))
+	require.NoError(t, err)
+
+	SetUTF8Charset(document)
+
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, html.Render(buf, document))
+
+	assert.Contains(t, buf.String(), `<meta charset="utf-8"/>`)
+	assert.Contains(t, buf.String(), `<meta http-equiv="content-type" content="text/html; charset=utf-8"/>`)
+	assert.Contains(t, buf.String(), `<meta name="description" content="charset=windows-1251"/>`)
+}
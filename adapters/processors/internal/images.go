@@ -0,0 +1,155 @@
+package internal
+
+import (
+	"bytes"
+	"image"
+	"image/gif"
+
+	"github.com/disintegration/imaging"
+	"github.com/gabriel-vasile/mimetype"
+	"go.uber.org/zap"
+
+	"github.com/derfenix/webarchive/config"
+)
+
+// DefaultImages is the images policy used until WithImages call, it matches the config defaults.
+var DefaultImages = config.Images{
+	MaxWidth:         1024,
+	MaxHeight:        1024,
+	Format:           config.ImageFormatJPEG,
+	Quality:          90,
+	KeepTransparency: true,
+	SkipAnimated:     true,
+}
+
+// rasterFormats are the image types which can be resized, keyed by mimetype.
+var rasterFormats = map[string]imaging.Format{
+	"image/jpeg": imaging.JPEG,
+	"image/png":  imaging.PNG,
+	"image/gif":  imaging.GIF,
+	"image/bmp":  imaging.BMP,
+	"image/tiff": imaging.TIFF,
+}
+
+// preprocessResource applies the images policy to the image resources, other resources are returned as is.
+func (m *MediaInline) preprocessResource(data []byte, mime *string) ([]byte, error) {
+	policy := m.images
+
+	if policy.Original {
+		return data, nil
+	}
+
+	detectedMime := mimetype.Detect(data).String()
+
+	sourceFormat, ok := rasterFormats[detectedMime]
+	if !ok {
+		return data, nil
+	}
+
+	if sourceFormat == imaging.GIF && policy.SkipAnimated && isAnimatedGIF(data) {
+		return data, nil
+	}
+
+	imageConfig, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		m.log.Error("failed to decode image config", zap.Error(err))
+
+		return data, nil
+	}
+
+	if !exceeds(imageConfig.Width, policy.MaxWidth) && !exceeds(imageConfig.Height, policy.MaxHeight) {
+		return data, nil
+	}
+
+	decodedImage, err := imaging.Decode(bytes.NewReader(data), imaging.AutoOrientation(true))
+	if err != nil {
+		m.log.Error("failed to decode image", zap.Error(err))
+
+		return data, nil
+	}
+
+	resized := imaging.Fit(decodedImage, limit(policy.MaxWidth), limit(policy.MaxHeight), imaging.Lanczos)
+
+	format := targetFormat(policy, sourceFormat, decodedImage)
+
+	buf := bytes.NewBuffer(nil)
+	if err := imaging.Encode(buf, resized, format, imaging.JPEGQuality(policy.Quality)); err != nil {
+		m.log.Error("failed to create resized image", zap.Error(err))
+
+		return data, nil
+	}
+
+	*mime = "image/" + formatName(format)
+
+	return buf.Bytes(), nil
+}
+
+func targetFormat(policy config.Images, sourceFormat imaging.Format, img image.Image) imaging.Format {
+	var format imaging.Format
+
+	switch policy.Format {
+	case config.ImageFormatPNG:
+		format = imaging.PNG
+	case config.ImageFormatKeep:
+		format = sourceFormat
+	default:
+		format = imaging.JPEG
+	}
+
+	if format == imaging.JPEG && policy.KeepTransparency && !isOpaque(img) {
+		format = imaging.PNG
+	}
+
+	// GIF encoder reduces colors to 256, so resized GIF is stored as PNG.
+	if format == imaging.GIF {
+		format = imaging.PNG
+	}
+
+	return format
+}
+
+func formatName(format imaging.Format) string {
+	switch format {
+	case imaging.JPEG:
+		return "jpeg"
+	case imaging.PNG:
+		return "png"
+	case imaging.BMP:
+		return "bmp"
+	case imaging.TIFF:
+		return "tiff"
+	default:
+		return "png"
+	}
+}
+
+func isOpaque(img image.Image) bool {
+	if opaque, ok := img.(interface{ Opaque() bool }); ok {
+		return opaque.Opaque()
+	}
+
+	return true
+}
+
+func isAnimatedGIF(data []byte) bool {
+	decoded, err := gif.DecodeAll(bytes.NewReader(data))
+	if err != nil {
+		return false
+	}
+
+	return len(decoded.Image) > 1
+}
+
+// exceeds reports whether the size is larger than the max value, zero max value means no limit.
+func exceeds(size int, maxSize int) bool {
+	return maxSize > 0 && size > maxSize
+}
+
+// limit converts zero max value to the imaging.Fit unlimited size.
+func limit(maxSize int) int {
+	if maxSize <= 0 {
+		return 1 << 30
+	}
+
+	return maxSize
+}
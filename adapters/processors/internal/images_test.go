@@ -0,0 +1,118 @@
+package internal
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/derfenix/webarchive/config"
+)
+
+func TestMediaInline_preprocessResource(t *testing.T) {
+	t.Parallel()
+
+	newPNG := func(t *testing.T, width, height int, alpha uint8) []byte {
+		t.Helper()
+
+		img := image.NewNRGBA(image.Rect(0, 0, width, height))
+		for x := 0; x < width; x++ {
+			for y := 0; y < height; y++ {
+				img.Set(x, y, color.NRGBA{R: 200, A: alpha})
+			}
+		}
+
+		buf := bytes.NewBuffer(nil)
+		require.NoError(t, png.Encode(buf, img))
+
+		return buf.Bytes()
+	}
+
+	policy := config.Images{
+		MaxWidth:         100,
+		MaxHeight:        100,
+		Format:           config.ImageFormatJPEG,
+		Quality:          80,
+		KeepTransparency: true,
+		SkipAnimated:     true,
+	}
+
+	process := func(t *testing.T, policy config.Images, data []byte) ([]byte, string, image.Config) {
+		t.Helper()
+
+		mime := "image/png"
+
+		result, err := NewMediaInline(zaptest.NewLogger(t), nil).WithImages(policy).preprocessResource(data, &mime)
+		require.NoError(t, err)
+
+		imageConfig, _, err := image.DecodeConfig(bytes.NewReader(result))
+		require.NoError(t, err)
+
+		return result, mime, imageConfig
+	}
+
+	t.Run("opaque image resized to jpeg", func(t *testing.T) {
+		t.Parallel()
+
+		_, mime, imageConfig := process(t, policy, newPNG(t, 400, 200, 255))
+
+		assert.Equal(t, "image/jpeg", mime)
+		assert.Equal(t, 100, imageConfig.Width)
+		assert.Equal(t, 50, imageConfig.Height)
+	})
+
+	t.Run("transparent image resized to png", func(t *testing.T) {
+		t.Parallel()
+
+		_, mime, imageConfig := process(t, policy, newPNG(t, 200, 400, 100))
+
+		assert.Equal(t, "image/png", mime)
+		assert.Equal(t, 50, imageConfig.Width)
+		assert.Equal(t, 100, imageConfig.Height)
+	})
+
+	t.Run("small image kept", func(t *testing.T) {
+		t.Parallel()
+
+		data := newPNG(t, 50, 50, 255)
+		result, mime, _ := process(t, policy, data)
+
+		assert.Equal(t, "image/png", mime)
+		assert.Equal(t, data, result)
+	})
+
+	t.Run("original", func(t *testing.T) {
+		t.Parallel()
+
+		data := newPNG(t, 400, 400, 255)
+		result, mime, _ := process(t, config.Images{Original: true}, data)
+
+		assert.Equal(t, "image/png", mime)
+		assert.Equal(t, data, result)
+	})
+
+	t.Run("animated gif kept", func(t *testing.T) {
+		t.Parallel()
+
+		palette := color.Palette{color.Black, color.White}
+		animation := &gif.GIF{
+			Image: []*image.Paletted{
+				image.NewPaletted(image.Rect(0, 0, 200, 200), palette),
+				image.NewPaletted(image.Rect(0, 0, 200, 200), palette),
+			},
+			Delay: []int{10, 10},
+		}
+
+		buf := bytes.NewBuffer(nil)
+		require.NoError(t, gif.EncodeAll(buf, animation))
+
+		result, _, _ := process(t, policy, buf.Bytes())
+		assert.Equal(t, buf.Bytes(), result)
+	})
+}
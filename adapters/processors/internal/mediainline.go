@@ -4,64 +4,252 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"regexp"
+	"slices"
 	"strings"
+	"sync"
 
-	"github.com/disintegration/imaging"
-	"github.com/gabriel-vasile/mimetype"
 	"go.uber.org/zap"
 	"golang.org/x/net/html"
+
+	"github.com/derfenix/webarchive/config"
 )
 
+// ResourceEncoder returns the value which replaces the resource reference in the document.
+type ResourceEncoder func(resourceURL string, mime string, data []byte) (string, error)
+
+// StylesheetReference converts the reference returned by ResourceEncoder, which is relative to the document,
+// to the reference used inside the stylesheet loaded from stylesheetURL.
+type StylesheetReference func(reference string, stylesheetURL string) string
+
+// cssMaxImportDepth limits the nested stylesheets processing, it also stops the @import cycles.
+const cssMaxImportDepth = 5
+
+var (
+	cssURLRe    = regexp.MustCompile(`url\(\s*(?:"([^"]*)"|'([^']*)'|([^)"'\s]*))\s*\)`)
+	cssImportRe = regexp.MustCompile(`@import\s+(?:"([^"]*)"|'([^']*)')`)
+)
+
+// DefaultConcurrency is the default limit of the parallel resource downloads.
+const DefaultConcurrency = 8
+
 type MediaInline struct {
-	log    *zap.Logger
-	getter func(context.Context, string) (*http.Response, error)
+	log          *zap.Logger
+	getter       func(context.Context, string) (*http.Response, error)
+	encode       ResourceEncoder
+	cssReference StylesheetReference
+	frameDepth   int
+	static       *StaticCleaner
+	images       config.Images
+
+	fetchLimit chan struct{}
+	mu         sync.Mutex
+	resources  map[string]*inlineResource
+}
+
+// inlineResource is fetched once for all its references on the page.
+type inlineResource struct {
+	// queued is set when the download is started by the prefetch walk, it is guarded by MediaInline.mu.
+	queued    bool
+	fetchOnce sync.Once
+	mime      string
+	data      []byte
+	finalURL  *url.URL
+	err       error
+
+	encodeOnce sync.Once
+	encoded    string
+	encodeErr  error
 }
 
 func NewMediaInline(log *zap.Logger, getter func(context.Context, string) (*http.Response, error)) *MediaInline {
-	return &MediaInline{log: log, getter: getter}
+	m := &MediaInline{
+		log:        log,
+		getter:     getter,
+		fetchLimit: make(chan struct{}, DefaultConcurrency),
+		resources:  make(map[string]*inlineResource),
+		images:     DefaultImages,
+	}
+	m.encode = m.dataURI
+
+	return m
+}
+
+// WithConcurrency sets the limit of the parallel resource downloads.
+func (m *MediaInline) WithConcurrency(concurrency int) *MediaInline {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	m.fetchLimit = make(chan struct{}, concurrency)
+
+	return m
+}
+
+// WithEncoder replaces the default data URI encoder, e.g. to store resources as separate files.
+func (m *MediaInline) WithEncoder(encode ResourceEncoder) *MediaInline {
+	m.encode = encode
+
+	return m
+}
+
+// WithStylesheetReference sets the converter of references inside stylesheets, it is needed when
+// the encoder returns relative paths.
+func (m *MediaInline) WithStylesheetReference(cssReference StylesheetReference) *MediaInline {
+	m.cssReference = cssReference
+
+	return m
 }
 
+// WithFrameDepth enables the iframe documents inlining into srcdoc attribute, depth limits the nested frames.
+func (m *MediaInline) WithFrameDepth(depth int) *MediaInline {
+	m.frameDepth = depth
+
+	return m
+}
+
+// WithImages sets the policy of the images embedded by the default encoder.
+func (m *MediaInline) WithImages(images config.Images) *MediaInline {
+	m.images = images
+
+	return m
+}
+
+// WithStatic makes the static document without scripts and blocked elements, see StaticCleaner.
+func (m *MediaInline) WithStatic(cleaner *StaticCleaner) *MediaInline {
+	m.static = cleaner
+
+	return m
+}
+
+// Inline embeds the resources into the document, which must be decoded to UTF-8 already, see DecodeHTML.
 func (m *MediaInline) Inline(ctx context.Context, reader io.Reader, pageURL string) (*html.Node, error) {
 	htmlNode, err := html.Parse(reader)
 	if err != nil {
 		return nil, fmt.Errorf("parse response body: %w", err)
 	}
 
+	SetUTF8Charset(htmlNode)
+
 	baseURL, err := url.Parse(pageURL)
 	if err != nil {
 		return nil, fmt.Errorf("parse page url: %w", err)
 	}
 
+	if m.static != nil {
+		m.static.Clean(htmlNode, baseURL)
+	}
+
 	m.visit(ctx, htmlNode, m.processorFunc, baseURL)
 
 	return htmlNode, nil
 }
 
 func (m *MediaInline) processorFunc(ctx context.Context, node *html.Node, baseURL *url.URL) error {
+	if node.Type != html.ElementNode {
+		return nil
+	}
+
+	m.processStyleAttr(ctx, node.Attr, baseURL)
+
+	if _, ok := lazyElements[node.Data]; ok {
+		promoteLazyAttrs(node)
+	}
+
 	switch node.Data {
 	case "link":
 		if err := m.processHref(ctx, node.Attr, baseURL); err != nil {
 			return fmt.Errorf("process link %s: %w", node.Attr, err)
 		}
 
-	case "script", "img":
-		if err := m.processSrc(ctx, node.Attr, baseURL); err != nil {
-			return fmt.Errorf("process script %s: %w", node.Attr, err)
+	case "script", "audio", "embed":
+		if err := m.processAttr(ctx, node.Attr, "src", baseURL); err != nil {
+			return fmt.Errorf("process %s src %s: %w", node.Data, node.Attr, err)
+		}
+
+	case "img", "source":
+		if err := m.processAttr(ctx, node.Attr, "src", baseURL); err != nil {
+			return fmt.Errorf("process %s src %s: %w", node.Data, node.Attr, err)
+		}
+
+		if err := m.processSrcset(ctx, node.Attr, baseURL); err != nil {
+			return fmt.Errorf("process %s srcset %s: %w", node.Data, node.Attr, err)
+		}
+
+	case "video":
+		for _, key := range []string{"src", "poster"} {
+			if err := m.processAttr(ctx, node.Attr, key, baseURL); err != nil {
+				return fmt.Errorf("process video %s %s: %w", key, node.Attr, err)
+			}
+		}
+
+	case "iframe":
+		if err := m.processFrame(ctx, node, baseURL); err != nil {
+			return fmt.Errorf("process iframe %s: %w", node.Attr, err)
 		}
 
 	case "a":
 		if err := m.processAHref(node.Attr, baseURL); err != nil {
 			return fmt.Errorf("process a href %s: %w", node.Attr, err)
 		}
+
+	case "style":
+		if text := node.FirstChild; text != nil && text.Type == html.TextNode {
+			text.Data = m.inlineCSS(ctx, text.Data, baseURL, nil)
+		}
 	}
 
 	return nil
 }
 
+func (m *MediaInline) processStyleAttr(ctx context.Context, attrs []html.Attribute, baseURL *url.URL) {
+	for idx, attr := range attrs {
+		if attr.Key == "style" && strings.Contains(attr.Val, "url(") {
+			attrs[idx].Val = m.inlineCSS(ctx, attr.Val, baseURL, nil)
+		}
+	}
+}
+
+// inlineCSS replaces url() and @import references in css. Stylesheets are the URLs of the stylesheet and
+// all stylesheets importing it, they are empty for the css from the document itself, which references are
+// relative to the document.
+func (m *MediaInline) inlineCSS(ctx context.Context, css string, baseURL *url.URL, stylesheets []string) string {
+	replace := func(re *regexp.Regexp, format string) {
+		css = re.ReplaceAllStringFunc(css, func(match string) string {
+			groups := re.FindStringSubmatch(match)
+			value := strings.TrimSpace(strings.Join(groups[1:], ""))
+
+			if value == "" || strings.HasPrefix(value, "#") || strings.HasPrefix(strings.ToLower(value), "data:") {
+				return match
+			}
+
+			encodedValue, err := m.load(ctx, baseURL, value, stylesheets)
+			if err != nil {
+				m.log.Error("process css resource", zap.String("url", value), zap.Error(err))
+
+				return match
+			}
+
+			if len(stylesheets) > 0 && m.cssReference != nil && encodedValue != value {
+				encodedValue = m.cssReference(encodedValue, stylesheets[len(stylesheets)-1])
+			}
+
+			return fmt.Sprintf(format, encodedValue)
+		})
+	}
+
+	// Imports go first, so url() inside replaced imports is not processed again.
+	replace(cssImportRe, `@import "%s"`)
+	replace(cssURLRe, `url("%s")`)
+
+	return css
+}
+
 func (m *MediaInline) processAHref(attrs []html.Attribute, baseURL *url.URL) error {
 	for idx, attr := range attrs {
 		switch attr.Key {
@@ -106,39 +294,59 @@ func (m *MediaInline) processHref(ctx context.Context, attrs []html.Attribute, b
 	return nil
 }
 
-func (m *MediaInline) processSrc(ctx context.Context, attrs []html.Attribute, baseURL *url.URL) error {
-	var shouldProcess bool
-	var value string
-	var valueIdx int
-
+func (m *MediaInline) processAttr(ctx context.Context, attrs []html.Attribute, key string, baseURL *url.URL) error {
 	for idx, attr := range attrs {
-		switch attr.Key {
-		case "src":
-			value = attr.Val
-			valueIdx = idx
-			shouldProcess = true
-		case "data-src":
-			value = attr.Val
+		if attr.Key != key || strings.HasPrefix(strings.ToLower(attr.Val), "data:") {
+			continue
 		}
-	}
 
-	if !shouldProcess {
-		return nil
-	}
+		encodedValue, err := m.loadAndEncode(ctx, baseURL, attr.Val)
+		if err != nil {
+			return err
+		}
 
-	encodedValue, err := m.loadAndEncode(ctx, baseURL, value)
-	if err != nil {
-		return err
+		attrs[idx].Val = encodedValue
 	}
 
-	attrs[valueIdx].Val = encodedValue
+	return nil
+}
+
+func (m *MediaInline) processSrcset(ctx context.Context, attrs []html.Attribute, baseURL *url.URL) error {
+	for idx, attr := range attrs {
+		if attr.Key != "srcset" {
+			continue
+		}
+
+		candidates := parseSrcset(attr.Val)
+
+		for i := range candidates {
+			candidate := &candidates[i]
+
+			if strings.HasPrefix(strings.ToLower(candidate.url), "data:") {
+				continue
+			}
+
+			encodedValue, err := m.loadAndEncode(ctx, baseURL, candidate.url)
+			if err != nil {
+				return err
+			}
+
+			candidate.url = encodedValue
+		}
+
+		attrs[idx].Val = formatSrcset(candidates)
+	}
 
 	return nil
 }
 
 func (m *MediaInline) loadAndEncode(ctx context.Context, baseURL *url.URL, value string) (string, error) {
-	mime := "text/plain"
+	return m.load(ctx, baseURL, value, nil)
+}
 
+// load fetches and encodes the resource. Stylesheets have their own references inlined, unless the import
+// depth limit is reached or the stylesheet imports itself.
+func (m *MediaInline) load(ctx context.Context, baseURL *url.URL, value string, stylesheets []string) (string, error) {
 	if value == "" {
 		return "", nil
 	}
@@ -148,108 +356,396 @@ func (m *MediaInline) loadAndEncode(ctx context.Context, baseURL *url.URL, value
 		return value, nil
 	}
 
-	response, err := m.getter(ctx, normalizedURL)
-	if err != nil {
-		m.log.Sugar().With(zap.Error(err)).Errorf("load %s", normalizedURL)
+	resource := m.fetch(ctx, normalizedURL)
+	if resource.err != nil {
 		return value, nil
 	}
 
-	defer func() {
-		_ = response.Body.Close()
-	}()
-
 	cleanMime := func(s string) string {
 		s, _, _ = strings.Cut(s, "+")
 		return s
 	}
 
+	// Stylesheet result depends on the importing stylesheets, so it is not shared.
+	if strings.HasPrefix(resource.mime, "text/css") {
+		data := resource.data
+
+		if len(stylesheets) < cssMaxImportDepth && !slices.Contains(stylesheets, normalizedURL) {
+			// Stylesheet references are relative to its final URL after redirects.
+			data = []byte(m.inlineCSS(ctx, string(data), resource.finalURL, append(slices.Clip(stylesheets), normalizedURL)))
+		}
+
+		encodedVal, err := m.encode(normalizedURL, cleanMime(resource.mime), data)
+		if err != nil {
+			return value, fmt.Errorf("encode resource: %w", err)
+		}
+
+		return encodedVal, nil
+	}
+
+	resource.encodeOnce.Do(func() {
+		resource.encoded, resource.encodeErr = m.encode(normalizedURL, cleanMime(resource.mime), resource.data)
+	})
+
+	if resource.encodeErr != nil {
+		return value, fmt.Errorf("encode resource: %w", resource.encodeErr)
+	}
+
+	return resource.encoded, nil
+}
+
+// fetch downloads the resource once, callers wait for the first download result. In the prefetch walk
+// the download is started in background and the returned resource has errPrefetch error.
+func (m *MediaInline) fetch(ctx context.Context, resourceURL string) *inlineResource {
+	m.mu.Lock()
+	resource, ok := m.resources[resourceURL]
+	if !ok {
+		resource = &inlineResource{}
+		m.resources[resourceURL] = resource
+	}
+
+	queued := resource.queued
+	prefetch := isPrefetch(ctx)
+
+	if prefetch {
+		resource.queued = true
+	}
+	m.mu.Unlock()
+
+	if prefetch {
+		if !queued {
+			m.prefetch(ctx, resource, resourceURL)
+		}
+
+		return &inlineResource{err: errPrefetch}
+	}
+
+	resource.fetchOnce.Do(func() {
+		if err := m.acquireFetch(ctx); err != nil {
+			resource.err = err
+
+			return
+		}
+
+		defer m.releaseFetch()
+
+		m.downloadResource(ctx, resource, resourceURL)
+	})
+
+	return resource
+}
+
+// prefetch starts the resource download, it waits for the free fetch slot, so the walk is not ahead of
+// the downloads too much.
+func (m *MediaInline) prefetch(ctx context.Context, resource *inlineResource, resourceURL string) {
+	if err := m.acquireFetch(ctx); err != nil {
+		return
+	}
+
+	go func() {
+		defer m.releaseFetch()
+
+		resource.fetchOnce.Do(func() {
+			m.downloadResource(ctx, resource, resourceURL)
+		})
+	}()
+}
+
+func (m *MediaInline) acquireFetch(ctx context.Context) error {
+	select {
+	case m.fetchLimit <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (m *MediaInline) releaseFetch() {
+	<-m.fetchLimit
+}
+
+func (m *MediaInline) downloadResource(ctx context.Context, resource *inlineResource, resourceURL string) {
+	resource.mime, resource.data, resource.finalURL, resource.err = m.download(ctx, resourceURL)
+	if resource.err != nil {
+		m.log.Sugar().With(zap.Error(resource.err)).Errorf("load %s", resourceURL)
+	}
+}
+
+func (m *MediaInline) download(ctx context.Context, resourceURL string) (string, []byte, *url.URL, error) {
+	mime := "text/plain"
+
+	response, err := m.getter(ctx, resourceURL)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
 	if ct := response.Header.Get("Content-Type"); ct != "" {
 		mime = ct
 	}
 
-	encodedVal, err := m.encodeResource(response.Body, &mime)
+	data, err := io.ReadAll(response.Body)
 	if err != nil {
-		return value, fmt.Errorf("encode resource: %w", err)
+		return "", nil, nil, fmt.Errorf("read data: %w", err)
 	}
 
-	return fmt.Sprintf("data:%s;base64, %s", cleanMime(mime), encodedVal), nil
+	if response.Request != nil {
+		return mime, data, response.Request.URL, nil
+	}
+
+	finalURL, err := url.Parse(resourceURL)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("parse url: %w", err)
+	}
+
+	return mime, data, finalURL, nil
+}
+
+// errPrefetch is the result of the resource requested by the prefetch walk, the node is not changed by it.
+var errPrefetch = errors.New("resource is prefetched")
+
+type prefetchKey struct{}
+
+func isPrefetch(ctx context.Context) bool {
+	prefetch, _ := ctx.Value(prefetchKey{}).(bool)
+
+	return prefetch
 }
 
+// visit processes all element nodes of the tree. The first walk starts the downloads of the referenced
+// resources in parallel, limited by the fetch limit, the second one changes the nodes using the results.
 func (m *MediaInline) visit(ctx context.Context, n *html.Node, proc func(context.Context, *html.Node, *url.URL) error, baseURL *url.URL) {
-	if err := proc(ctx, n, baseURL); err != nil {
-		m.log.Error("process error", zap.Error(err))
+	prefetchCtx := context.WithValue(ctx, prefetchKey{}, true)
+
+	walkElements(n, func(node *html.Node) {
+		_ = proc(prefetchCtx, node, baseURL)
+	})
+
+	walkElements(n, func(node *html.Node) {
+		if err := proc(ctx, node, baseURL); err != nil {
+			m.log.Error("process error", zap.Error(err))
+		}
+	})
+}
+
+func walkElements(n *html.Node, fn func(*html.Node)) {
+	for ; n != nil; n = n.NextSibling {
+		if n.Type == html.ElementNode || n.Type == html.DocumentNode {
+			fn(n)
+		}
+
+		walkElements(n.FirstChild, fn)
 	}
+}
 
-	if n.FirstChild != nil {
-		m.visit(ctx, n.FirstChild, proc, baseURL)
+type frameDepthKey struct{}
+
+// processFrame inlines the frame document with all its resources into srcdoc attribute. Frame URL is made
+// absolute in any case, so the frame still works online when it is not inlined.
+func (m *MediaInline) processFrame(ctx context.Context, node *html.Node, baseURL *url.URL) error {
+	src, ok := attrValue(node.Attr, "src")
+	if !ok {
+		return nil
 	}
 
-	if n.NextSibling != nil {
-		m.visit(ctx, n.NextSibling, proc, baseURL)
+	frameURL := normalizeURL(src, baseURL)
+	if frameURL == "" {
+		return nil
 	}
-}
 
-func normalizeURL(resourceURL string, base *url.URL) string {
-	if strings.HasPrefix(resourceURL, "//") {
-		return "https:" + resourceURL
+	for idx, attr := range node.Attr {
+		if attr.Key == "src" {
+			node.Attr[idx].Val = frameURL
+		}
 	}
 
-	if strings.HasPrefix(resourceURL, "about:") {
-		return ""
+	depth, _ := ctx.Value(frameDepthKey{}).(int)
+	if depth >= m.frameDepth {
+		return nil
 	}
 
-	parsedResourceURL, err := url.Parse(resourceURL)
-	if err != nil {
-		return resourceURL
+	if _, ok := attrValue(node.Attr, "srcdoc"); ok {
+		return nil
 	}
 
-	reference := base.ResolveReference(parsedResourceURL)
+	resource := m.fetch(ctx, frameURL)
+	if resource.err != nil {
+		return nil
+	}
 
-	return reference.String()
-}
+	if !strings.HasPrefix(resource.mime, "text/html") {
+		return nil
+	}
 
-func (m *MediaInline) encodeResource(r io.Reader, mime *string) (string, error) {
-	all, err := io.ReadAll(r)
+	decoded, _, err := DecodeHTML(bytes.NewReader(resource.data), resource.mime)
 	if err != nil {
-		return "", fmt.Errorf("read data: %w", err)
+		return fmt.Errorf("decode frame document: %w", err)
 	}
 
-	all, err = m.preprocessResource(all, mime)
+	document, err := html.Parse(decoded)
 	if err != nil {
-		return "", fmt.Errorf("preprocess resource: %w", err)
+		return fmt.Errorf("parse frame document: %w", err)
 	}
 
-	return base64.StdEncoding.EncodeToString(all), nil
+	SetUTF8Charset(document)
+
+	if m.static != nil {
+		m.static.Clean(document, resource.finalURL)
+	}
+
+	m.visit(context.WithValue(ctx, frameDepthKey{}, depth+1), document, m.processorFunc, resource.finalURL)
+
+	buf := bytes.NewBuffer(nil)
+	if err := html.Render(buf, document); err != nil {
+		return fmt.Errorf("render frame document: %w", err)
+	}
+
+	node.Attr = append(node.Attr, html.Attribute{Key: "srcdoc", Val: buf.String()})
+
+	return nil
 }
 
-func (m *MediaInline) preprocessResource(data []byte, mime *string) ([]byte, error) {
-	detectedMime := mimetype.Detect(data)
+// lazyElements are the elements which attributes are promoted by promoteLazyAttrs.
+var lazyElements = map[string]struct{}{
+	"img": {}, "source": {}, "video": {}, "audio": {}, "iframe": {},
+}
 
-	switch {
-	case strings.HasPrefix(detectedMime.String(), "image"):
-		decodedImage, err := imaging.Decode(bytes.NewBuffer(data))
-		if err != nil {
-			m.log.Error("failed to decode image", zap.Error(err))
+// lazyAttrs maps the lazy loading attributes to the real ones, in priority order.
+var lazyAttrs = []struct{ from, to string }{
+	{from: "data-src", to: "src"},
+	{from: "data-original", to: "src"},
+	{from: "data-srcset", to: "srcset"},
+}
+
+// promoteLazyAttrs replaces the placeholder values with ones from the lazy loading attributes, which are
+// removed then, so lazy loading scripts can't replace the inlined values.
+func promoteLazyAttrs(node *html.Node) {
+	promoted := make(map[string]struct{})
+
+	for _, lazy := range lazyAttrs {
+		value, ok := attrValue(node.Attr, lazy.from)
+		if !ok {
+			continue
+		}
+
+		node.Attr = removeAttr(node.Attr, lazy.from)
+
+		if _, done := promoted[lazy.to]; done || strings.TrimSpace(value) == "" {
+			continue
+		}
+
+		promoted[lazy.to] = struct{}{}
+		node.Attr = append(removeAttr(node.Attr, lazy.to), html.Attribute{Key: lazy.to, Val: value})
+	}
+}
 
-			return data, nil
+func attrValue(attrs []html.Attribute, key string) (string, bool) {
+	for _, attr := range attrs {
+		if attr.Key == key {
+			return attr.Val, true
 		}
+	}
 
-		if size := decodedImage.Bounds().Size(); size.X > 1024 || size.Y > 1024 {
-			thumbnail := imaging.Thumbnail(decodedImage, 1024, 1024, imaging.Lanczos)
-			buf := bytes.NewBuffer(nil)
+	return "", false
+}
 
-			if err := imaging.Encode(buf, thumbnail, imaging.JPEG, imaging.JPEGQuality(90)); err != nil {
-				m.log.Error("failed to create resized image", zap.Error(err))
+func removeAttr(attrs []html.Attribute, key string) []html.Attribute {
+	result := attrs[:0]
 
-				return data, nil
+	for _, attr := range attrs {
+		if attr.Key != key {
+			result = append(result, attr)
+		}
+	}
+
+	return result
+}
+
+type srcsetCandidate struct {
+	url        string
+	descriptor string
+}
+
+// parseSrcset splits srcset value into image candidates. URLs may contain commas, e.g. data URIs,
+// so candidates are separated by commas after the URL only.
+func parseSrcset(srcset string) []srcsetCandidate {
+	var candidates []srcsetCandidate
+
+	for pos := 0; pos < len(srcset); {
+		for pos < len(srcset) && (isSpace(srcset[pos]) || srcset[pos] == ',') {
+			pos++
+		}
+
+		start := pos
+		for pos < len(srcset) && !isSpace(srcset[pos]) {
+			pos++
+		}
+
+		candidate := srcsetCandidate{url: srcset[start:pos]}
+
+		if trimmed := strings.TrimRight(candidate.url, ","); trimmed != candidate.url {
+			candidate.url = trimmed
+		} else {
+			start = pos
+			for pos < len(srcset) && srcset[pos] != ',' {
+				pos++
 			}
 
-			*mime = "image/jpeg"
-			m.log.Info("Resized")
+			candidate.descriptor = strings.TrimSpace(srcset[start:pos])
+		}
 
-			return buf.Bytes(), nil
+		if candidate.url != "" {
+			candidates = append(candidates, candidate)
 		}
 	}
 
-	return data, nil
+	return candidates
+}
+
+func formatSrcset(candidates []srcsetCandidate) string {
+	parts := make([]string, len(candidates))
+
+	for i, candidate := range candidates {
+		parts[i] = strings.TrimSpace(candidate.url + " " + candidate.descriptor)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+func normalizeURL(resourceURL string, base *url.URL) string {
+	if strings.HasPrefix(resourceURL, "//") {
+		return "https:" + resourceURL
+	}
+
+	if strings.HasPrefix(resourceURL, "about:") {
+		return ""
+	}
+
+	parsedResourceURL, err := url.Parse(resourceURL)
+	if err != nil {
+		return resourceURL
+	}
+
+	reference := base.ResolveReference(parsedResourceURL)
+
+	return reference.String()
+}
+
+func (m *MediaInline) dataURI(_ string, mime string, data []byte) (string, error) {
+	data, err := m.preprocessResource(data, &mime)
+	if err != nil {
+		return "", fmt.Errorf("preprocess resource: %w", err)
+	}
+
+	// Spaces in mime parameters would break srcset candidates.
+	mime = strings.ReplaceAll(mime, " ", "")
+
+	return fmt.Sprintf("data:%s;base64,%s", mime, base64.StdEncoding.EncodeToString(data)), nil
 }
@@ -0,0 +1,293 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"golang.org/x/net/html"
+)
+
+func TestMediaInline_InlineCSS(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/css/style.css", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/css; charset=utf-8")
+		_, _ = w.Write([]byte(`@import "base.css"; @font-face { src: url('../fonts/font.woff2'); }`))
+	})
+	mux.HandleFunc("/css/base.css", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/css")
+		// Import cycle is stopped by the depth limit.
+		_, _ = w.Write([]byte(`@import url(style.css); body { background: url(bg.png) }`))
+	})
+	mux.HandleFunc("/css/bg.png", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("background"))
+	})
+	mux.HandleFunc("/fonts/font.woff2", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("font"))
+	})
+	mux.HandleFunc("/icon.png", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("icon"))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	getter := func(ctx context.Context, url string) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return server.Client().Do(req)
+	}
+
+	page := `<html><head><link rel="stylesheet" href="css/style.css">` +
+		`<style>.icon { background: url("icon.png") } .mask { mask: url(#mask) }</style></head>` +
+		`<body><div style="background-image: url(/icon.png)"></div></body></html>`
+
+	document, err := NewMediaInline(zaptest.NewLogger(t), getter).Inline(context.Background(), strings.NewReader(page), server.URL+"/")
+	require.NoError(t, err)
+
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, html.Render(buf, document))
+
+	result := decodeDataURIs(t, buf.String())
+
+	assert.Contains(t, result, `.icon { background: url("icon") }`)
+	assert.Contains(t, result, `url(#mask)`)
+	assert.Contains(t, result, `style="background-image: url(&#34;icon&#34;)"`)
+	assert.Contains(t, result, `src: url("font")`)
+	assert.Contains(t, result, `body { background: url("background") }`)
+}
+
+var dataURIRe = regexp.MustCompile(`data:[^,]*;base64,([A-Za-z0-9+/=]+)`)
+
+// decodeDataURIs replaces data URIs with their decoded content, recursively.
+func decodeDataURIs(t *testing.T, s string) string {
+	t.Helper()
+
+	for dataURIRe.MatchString(s) {
+		s = dataURIRe.ReplaceAllStringFunc(s, func(match string) string {
+			data, err := base64.StdEncoding.DecodeString(dataURIRe.FindStringSubmatch(match)[1])
+			require.NoError(t, err)
+
+			return string(data)
+		})
+	}
+
+	return s
+}
+
+func TestMediaInline_InlineMedia(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	for _, name := range []string{"small.png", "large.png", "lazy.png", "poster.png", "video.mp4", "audio.ogg"} {
+		mux.HandleFunc("/"+name, func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(name))
+		})
+	}
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	getter := func(ctx context.Context, url string) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return server.Client().Do(req)
+	}
+
+	page := `<html><body>` +
+		`<picture><source srcset="small.png 1x, large.png 2x" type="image/png"><img src="placeholder.gif" data-src="lazy.png"></picture>` +
+		`<img data-srcset="small.png 480w,large.png 1024w" sizes="50vw">` +
+		`<video poster="poster.png"><source src="video.mp4"></video>` +
+		`<audio src="audio.ogg"></audio>` +
+		`<iframe data-src="/frame"></iframe>` +
+		`</body></html>`
+
+	document, err := NewMediaInline(zaptest.NewLogger(t), getter).Inline(context.Background(), strings.NewReader(page), server.URL+"/")
+	require.NoError(t, err)
+
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, html.Render(buf, document))
+
+	rendered := buf.String()
+	assert.NotContains(t, rendered, "data-src")
+	assert.NotContains(t, rendered, "placeholder.gif")
+
+	result := decodeDataURIs(t, rendered)
+
+	assert.Contains(t, result, `<source srcset="small.png 1x, large.png 2x" type="image/png"/>`)
+	assert.Contains(t, result, `<img src="lazy.png"/>`)
+	assert.Contains(t, result, `<img sizes="50vw" srcset="small.png 480w, large.png 1024w"/>`)
+	assert.Contains(t, result, `<video poster="poster.png"><source src="video.mp4"/></video>`)
+	assert.Contains(t, result, `<audio src="audio.ogg"></audio>`)
+	assert.Contains(t, result, `<iframe src="`+server.URL+`/frame"></iframe>`)
+}
+
+func TestParseSrcset(t *testing.T) {
+	t.Parallel()
+
+	candidates := parseSrcset(" a.png 1x,b.png  2x , data:image/png;base64,AAAA 3x, c.png, d.png,")
+
+	assert.Equal(t, []srcsetCandidate{
+		{url: "a.png", descriptor: "1x"},
+		{url: "b.png", descriptor: "2x"},
+		{url: "data:image/png;base64,AAAA", descriptor: "3x"},
+		{url: "c.png"},
+		{url: "d.png"},
+	}, candidates)
+
+	assert.Equal(t, "a.png 1x, b.png 2x, data:image/png;base64,AAAA 3x, c.png, d.png", formatSrcset(candidates))
+}
+
+func TestMediaInline_ConcurrentFetch(t *testing.T) {
+	t.Parallel()
+
+	const concurrency = 2
+
+	var (
+		mu       sync.Mutex
+		requests = make(map[string]int)
+		active   atomic.Int32
+		maxSeen  atomic.Int32
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := active.Add(1)
+		defer active.Add(-1)
+
+		for seen := maxSeen.Load(); current > seen && !maxSeen.CompareAndSwap(seen, current); seen = maxSeen.Load() {
+		}
+
+		mu.Lock()
+		requests[r.URL.Path]++
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+		_, _ = w.Write([]byte(r.URL.Path))
+	}))
+	t.Cleanup(server.Close)
+
+	getter := func(ctx context.Context, url string) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return server.Client().Do(req)
+	}
+
+	page := strings.Builder{}
+	for i := 0; i < 10; i++ {
+		_, _ = fmt.Fprintf(&page, `<img src="/icon.png"><img src="/image%d.png">`, i)
+	}
+
+	document, err := NewMediaInline(zaptest.NewLogger(t), getter).
+		WithConcurrency(concurrency).
+		Inline(context.Background(), strings.NewReader(page.String()), server.URL+"/")
+	require.NoError(t, err)
+
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, html.Render(buf, document))
+
+	result := decodeDataURIs(t, buf.String())
+	assert.Equal(t, 10, strings.Count(result, `<img src="/icon.png"/>`))
+	assert.Contains(t, result, `<img src="/image9.png"/>`)
+
+	assert.Len(t, requests, 11)
+
+	for path, count := range requests {
+		assert.Equal(t, 1, count, path)
+	}
+
+	// The downloads run in parallel up to the limit.
+	assert.Equal(t, int32(concurrency), maxSeen.Load())
+}
+
+func TestMediaInline_InlineFrames(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/embed/frame.html", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(`<html><body><img src="image.png"><iframe src="/embed/frame.html"></iframe></body></html>`))
+	})
+	mux.HandleFunc("/embed/image.png", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("image"))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	getter := func(ctx context.Context, url string) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return server.Client().Do(req)
+	}
+
+	page := `<html><body><iframe src="embed/frame.html"></iframe></body></html>`
+
+	document, err := NewMediaInline(zaptest.NewLogger(t), getter).
+		WithFrameDepth(2).
+		Inline(context.Background(), strings.NewReader(page), server.URL+"/")
+	require.NoError(t, err)
+
+	var frames []*html.Node
+
+	var find func(n *html.Node)
+	find = func(n *html.Node) {
+		for ; n != nil; n = n.NextSibling {
+			if n.Type == html.ElementNode && n.Data == "iframe" {
+				frames = append(frames, n)
+
+				srcdoc, ok := attrValue(n.Attr, "srcdoc")
+				if !ok {
+					continue
+				}
+
+				assert.Contains(t, decodeDataURIs(t, srcdoc), `<img src="image"/>`)
+
+				frame, err := html.Parse(strings.NewReader(srcdoc))
+				require.NoError(t, err)
+
+				find(frame)
+			}
+
+			find(n.FirstChild)
+		}
+	}
+
+	find(document)
+
+	// Frame includes itself, it is inlined twice and the third one is left as a link only.
+	require.Len(t, frames, 3)
+
+	for _, frame := range frames {
+		src, _ := attrValue(frame.Attr, "src")
+		assert.Equal(t, server.URL+"/embed/frame.html", src)
+	}
+
+	_, ok := attrValue(frames[2].Attr, "srcdoc")
+	assert.False(t, ok)
+}
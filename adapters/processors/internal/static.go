@@ -0,0 +1,254 @@
+package internal
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/andybalholm/cascadia"
+	"golang.org/x/net/html"
+)
+
+// DefaultBlockedHosts are the common ad and tracker hosts, subdomains are blocked too.
+var DefaultBlockedHosts = []string{
+	"doubleclick.net",
+	"googlesyndication.com",
+	"googleadservices.com",
+	"google-analytics.com",
+	"googletagmanager.com",
+	"googletagservices.com",
+	"adservice.google.com",
+	"connect.facebook.net",
+	"amazon-adsystem.com",
+	"adnxs.com",
+	"criteo.com",
+	"criteo.net",
+	"taboola.com",
+	"outbrain.com",
+	"scorecardresearch.com",
+	"quantserve.com",
+	"hotjar.com",
+	"mc.yandex.ru",
+	"top-fwz1.mail.ru",
+	"pubmatic.com",
+	"rubiconproject.com",
+	"moatads.com",
+	"chartbeat.com",
+	"adform.net",
+}
+
+// DefaultBlockedSelectors match the common ad containers.
+var DefaultBlockedSelectors = []string{
+	"ins.adsbygoogle",
+	"[id^='google_ads_']",
+	"[id^='div-gpt-ad']",
+	"amp-ad",
+	"amp-analytics",
+}
+
+// StaticCleaner makes the document static: removes scripts, event handlers, tracking pixels and blocked
+// elements. Blocked elements are the ones matching the selectors or loading resources from the blocked hosts.
+type StaticCleaner struct {
+	hosts     []string
+	selectors []cascadia.Sel
+}
+
+func NewStaticCleaner(hosts []string, selectors []string) (*StaticCleaner, error) {
+	cleaner := &StaticCleaner{hosts: make([]string, 0, len(hosts))}
+
+	for _, host := range hosts {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			cleaner.hosts = append(cleaner.hosts, host)
+		}
+	}
+
+	for _, selector := range selectors {
+		if strings.TrimSpace(selector) == "" {
+			continue
+		}
+
+		sel, err := cascadia.Parse(selector)
+		if err != nil {
+			return nil, fmt.Errorf("parse selector %q: %w", selector, err)
+		}
+
+		cleaner.selectors = append(cleaner.selectors, sel)
+	}
+
+	return cleaner, nil
+}
+
+// staticResourceAttrs are the attributes with the URLs checked against the blocked hosts.
+var staticResourceAttrs = map[string]struct{}{
+	"src": {}, "href": {}, "data-src": {}, "poster": {}, "action": {}, "data": {},
+}
+
+func (c *StaticCleaner) Clean(document *html.Node, baseURL *url.URL) {
+	c.unwrapNoscript(document)
+
+	var removed []*html.Node
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		for ; n != nil; n = n.NextSibling {
+			if n.Type == html.ElementNode {
+				if c.shouldRemove(n, baseURL) {
+					removed = append(removed, n)
+
+					continue
+				}
+
+				n.Attr = cleanAttrs(n.Attr)
+			}
+
+			walk(n.FirstChild)
+		}
+	}
+
+	walk(document)
+
+	for _, node := range removed {
+		node.Parent.RemoveChild(node)
+	}
+}
+
+func (c *StaticCleaner) shouldRemove(n *html.Node, baseURL *url.URL) bool {
+	switch n.Data {
+	case "script":
+		return true
+
+	case "link":
+		rel, _ := attrValue(n.Attr, "rel")
+		as, _ := attrValue(n.Attr, "as")
+
+		if rel == "modulepreload" || ((rel == "preload" || rel == "prefetch") && as == "script") {
+			return true
+		}
+
+	case "img":
+		if isTrackingPixel(n) {
+			return true
+		}
+	}
+
+	for _, attr := range n.Attr {
+		if _, ok := staticResourceAttrs[attr.Key]; ok && c.blockedURL(attr.Val, baseURL) {
+			return true
+		}
+	}
+
+	for _, sel := range c.selectors {
+		if sel.Match(n) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *StaticCleaner) blockedURL(value string, baseURL *url.URL) bool {
+	if len(c.hosts) == 0 || value == "" {
+		return false
+	}
+
+	parsedURL, err := url.Parse(normalizeURL(strings.TrimSpace(value), baseURL))
+	if err != nil {
+		return false
+	}
+
+	host := strings.ToLower(parsedURL.Hostname())
+
+	for _, blocked := range c.hosts {
+		if host == blocked || strings.HasSuffix(host, "."+blocked) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// unwrapNoscript replaces noscript elements with their content, which is shown when scripts are disabled.
+// Parser keeps the noscript content as text, so it is parsed again.
+func (c *StaticCleaner) unwrapNoscript(document *html.Node) {
+	var noscripts []*html.Node
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		for ; n != nil; n = n.NextSibling {
+			if n.Type == html.ElementNode && n.Data == "noscript" {
+				noscripts = append(noscripts, n)
+
+				continue
+			}
+
+			walk(n.FirstChild)
+		}
+	}
+
+	walk(document)
+
+	for _, noscript := range noscripts {
+		var content strings.Builder
+
+		for child := noscript.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type == html.TextNode {
+				content.WriteString(child.Data)
+			} else {
+				_ = html.Render(&content, child)
+			}
+		}
+
+		parent := noscript.Parent
+		if parent.Type != html.ElementNode {
+			parent = &html.Node{Type: html.ElementNode, Data: "body"}
+		}
+
+		nodes, err := html.ParseFragment(strings.NewReader(content.String()), parent)
+		if err != nil {
+			continue
+		}
+
+		for _, node := range nodes {
+			noscript.Parent.InsertBefore(node, noscript)
+		}
+
+		noscript.Parent.RemoveChild(noscript)
+	}
+}
+
+// cleanAttrs removes event handlers and replaces javascript: URLs.
+func cleanAttrs(attrs []html.Attribute) []html.Attribute {
+	result := attrs[:0]
+
+	for _, attr := range attrs {
+		if strings.HasPrefix(strings.ToLower(attr.Key), "on") {
+			continue
+		}
+
+		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(attr.Val)), "javascript:") {
+			attr.Val = "#"
+		}
+
+		result = append(result, attr)
+	}
+
+	return result
+}
+
+// isTrackingPixel detects the images with size not larger than 1x1 pixel.
+func isTrackingPixel(n *html.Node) bool {
+	width, widthOK := attrValue(n.Attr, "width")
+	height, heightOK := attrValue(n.Attr, "height")
+
+	if !widthOK || !heightOK {
+		return false
+	}
+
+	isTiny := func(value string) bool {
+		value = strings.TrimSuffix(strings.TrimSpace(value), "px")
+
+		return value == "0" || value == "1"
+	}
+
+	return isTiny(width) && isTiny(height)
+}
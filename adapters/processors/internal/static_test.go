@@ -0,0 +1,53 @@
+package internal
+
+import (
+	"bytes"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+)
+
+func TestStaticCleaner_Clean(t *testing.T) {
+	t.Parallel()
+
+	cleaner, err := NewStaticCleaner(
+		slices.Concat(DefaultBlockedHosts, []string{"tracker.example.org"}),
+		slices.Concat(DefaultBlockedSelectors, []string{".promo"}),
+	)
+	require.NoError(t, err)
+
+	page := `<html><head><script src="app.js"></script><link rel="preload" as="script" href="app.js">` +
+		`<link rel="stylesheet" href="style.css"></head>` +
+		`<body onload="init()"><a href="javascript:void(0)" onclick="go()">link</a>` +
+		`<img src="pixel.gif" width="1" height="1"><img src="photo.jpg" width="100" height="1">` +
+		`<noscript><img src="https://tracker.example.org/p.gif"><img src="fallback.png"></noscript>` +
+		`<iframe src="https://ad.doubleclick.net/frame"></iframe><object data="https://tracker.example.org/widget.swf"></object>` +
+		`<ins class="adsbygoogle"></ins><div class="promo">buy</div><p>text</p></body></html>`
+
+	document, err := html.Parse(strings.NewReader(page))
+	require.NoError(t, err)
+
+	baseURL, err := url.Parse("https://example.com/")
+	require.NoError(t, err)
+
+	cleaner.Clean(document, baseURL)
+
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, html.Render(buf, document))
+
+	assert.Equal(t, `<html><head><link rel="stylesheet" href="style.css"/></head>`+
+		`<body><a href="#">link</a><img src="photo.jpg" width="100" height="1"/><img src="fallback.png"/>`+
+		`<p>text</p></body></html>`, buf.String())
+}
+
+func TestNewStaticCleaner_InvalidSelector(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewStaticCleaner(nil, []string{"div["})
+	assert.Error(t, err)
+}
@@ -0,0 +1,162 @@
+package internal
+
+import (
+	"bytes"
+	"crypto/sha1" //nolint:gosec // sha1 is the digest algorithm used by the WARC tooling
+	"encoding/base32"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	warcVersion   = "WARC/1.1"
+	warcSoftware  = "webarchive"
+	warcConformTo = "http://iipc.github.io/warc-specifications/specifications/warc-format/warc-1.1/"
+)
+
+// WARCWriter writes ISO 28500 WARC/1.1 records. It is safe for concurrent use.
+type WARCWriter struct {
+	mu         sync.Mutex
+	w          io.Writer
+	warcinfoID string
+}
+
+func NewWARCWriter(w io.Writer) *WARCWriter {
+	return &WARCWriter{w: w}
+}
+
+// WriteInfo writes the warcinfo record, which is referenced by all records written after it.
+func (w *WARCWriter) WriteInfo(filename string) error {
+	block := bytes.NewBuffer(nil)
+	_, _ = fmt.Fprintf(block, "software: %s\r\n", warcSoftware)
+	_, _ = fmt.Fprintf(block, "format: WARC File Format 1.1\r\n")
+	_, _ = fmt.Fprintf(block, "conformsTo: %s\r\n", warcConformTo)
+
+	id := newRecordID()
+
+	if err := w.writeRecord([][2]string{
+		{"WARC-Type", "warcinfo"},
+		{"WARC-Record-ID", id},
+		{"WARC-Date", warcDate(time.Now())},
+		{"WARC-Filename", filename},
+		{"Content-Type", "application/warc-fields"},
+	}, block.Bytes()); err != nil {
+		return fmt.Errorf("write warcinfo record: %w", err)
+	}
+
+	w.mu.Lock()
+	w.warcinfoID = id
+	w.mu.Unlock()
+
+	return nil
+}
+
+// WriteExchange writes a response record and the request record concurrent to it.
+// The body is the already read response body, the one in the response is ignored.
+func (w *WARCWriter) WriteExchange(response *http.Response, body []byte, date time.Time) error {
+	request := response.Request
+	targetURI := request.URL.String()
+
+	requestBlock, err := httputil.DumpRequestOut(request, false)
+	if err != nil {
+		return fmt.Errorf("dump request: %w", err)
+	}
+
+	responseBlock := bytes.NewBuffer(nil)
+	_, _ = fmt.Fprintf(responseBlock, "%s %s\r\n", response.Proto, response.Status)
+
+	headers := response.Header.Clone()
+	// The transport decodes chunked and compressed bodies, so describe the body as it is stored.
+	headers.Del("Transfer-Encoding")
+	headers.Del("Content-Encoding")
+	headers.Set("Content-Length", strconv.Itoa(len(body)))
+
+	if err := headers.Write(responseBlock); err != nil {
+		return fmt.Errorf("write response headers: %w", err)
+	}
+
+	responseBlock.WriteString("\r\n")
+	responseBlock.Write(body)
+
+	w.mu.Lock()
+	warcinfoID := w.warcinfoID
+	w.mu.Unlock()
+
+	responseID := newRecordID()
+
+	if err := w.writeRecord([][2]string{
+		{"WARC-Type", "response"},
+		{"WARC-Record-ID", responseID},
+		{"WARC-Date", warcDate(date)},
+		{"WARC-Target-URI", targetURI},
+		{"WARC-Warcinfo-ID", warcinfoID},
+		{"WARC-Block-Digest", digest(responseBlock.Bytes())},
+		{"WARC-Payload-Digest", digest(body)},
+		{"Content-Type", "application/http;msgtype=response"},
+	}, responseBlock.Bytes()); err != nil {
+		return fmt.Errorf("write response record: %w", err)
+	}
+
+	if err := w.writeRecord([][2]string{
+		{"WARC-Type", "request"},
+		{"WARC-Record-ID", newRecordID()},
+		{"WARC-Date", warcDate(date)},
+		{"WARC-Target-URI", targetURI},
+		{"WARC-Warcinfo-ID", warcinfoID},
+		{"WARC-Concurrent-To", responseID},
+		{"WARC-Block-Digest", digest(requestBlock)},
+		{"Content-Type", "application/http;msgtype=request"},
+	}, requestBlock); err != nil {
+		return fmt.Errorf("write request record: %w", err)
+	}
+
+	return nil
+}
+
+func (w *WARCWriter) writeRecord(fields [][2]string, block []byte) error {
+	buf := bytes.NewBuffer(make([]byte, 0, len(block)+512))
+
+	buf.WriteString(warcVersion + "\r\n")
+
+	for _, field := range fields {
+		if field[1] == "" {
+			continue
+		}
+
+		_, _ = fmt.Fprintf(buf, "%s: %s\r\n", field[0], field[1])
+	}
+
+	_, _ = fmt.Fprintf(buf, "Content-Length: %d\r\n\r\n", len(block))
+	buf.Write(block)
+	buf.WriteString("\r\n\r\n")
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, err := w.w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+
+	return nil
+}
+
+func newRecordID() string {
+	return "<urn:uuid:" + uuid.New().String() + ">"
+}
+
+func warcDate(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func digest(data []byte) string {
+	sum := sha1.Sum(data) //nolint:gosec // see import comment
+
+	return "sha1:" + base32.StdEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,80 @@
+package processors
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	md "github.com/JohannesKaufmann/html-to-markdown"
+	"github.com/go-shiori/go-readability"
+	"gopkg.in/yaml.v3"
+
+	"github.com/derfenix/webarchive/entity"
+)
+
+func NewMarkdown(client *http.Client) *Markdown {
+	return &Markdown{client: client}
+}
+
+type Markdown struct {
+	client *http.Client
+}
+
+type markdownFrontMatter struct {
+	Title    string    `yaml:"title"`
+	URL      string    `yaml:"url"`
+	Captured time.Time `yaml:"captured"`
+}
+
+func (m *Markdown) Process(ctx context.Context, page *entity.PageBase, cache *entity.Cache) ([]entity.File, error) {
+	reader, err := pageDocument(ctx, m.client, page, cache)
+	if err != nil {
+		return nil, err
+	}
+
+	pageURL, err := url.Parse(page.URL)
+	if err != nil {
+		return nil, fmt.Errorf("parse page url: %w", err)
+	}
+
+	article, err := readability.FromReader(reader, pageURL)
+	if err != nil {
+		return nil, fmt.Errorf("extract article: %w", err)
+	}
+
+	content, err := md.NewConverter(md.DomainFromURL(page.URL), true, nil).ConvertString(article.Content)
+	if err != nil {
+		return nil, fmt.Errorf("convert to markdown: %w", err)
+	}
+
+	title := page.Meta.Title
+	if title == "" {
+		title = article.Title
+	}
+
+	frontMatter, err := yaml.Marshal(markdownFrontMatter{
+		Title:    title,
+		URL:      page.URL,
+		Captured: page.Created.UTC(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal front matter: %w", err)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	buf.WriteString("---\n")
+	buf.Write(frontMatter)
+	buf.WriteString("---\n\n")
+
+	if title != "" {
+		_, _ = fmt.Fprintf(buf, "# %s\n\n", title)
+	}
+
+	buf.WriteString(content)
+	buf.WriteString("\n")
+
+	return []entity.File{entity.NewFile("page.md", buf.Bytes())}, nil
+}
@@ -0,0 +1,57 @@
+package processors
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/derfenix/webarchive/entity"
+)
+
+const articleHTML = `<html>
+<head><title>Article title</title></head>
+<body>
+<nav><a href="/">Home</a> <a href="/about">About</a></nav>
+<article>
+<h1>Article title</h1>
+<p>First paragraph of the article with <a href="/link">a relative link</a>. It has to be long enough
+to be recognized as the main content of the page by the extraction algorithm, so there is some filler text.</p>
+<p>Second paragraph of the article. Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod
+tempor incididunt ut labore et dolore magna aliqua. Ut enim ad minim veniam, quis nostrud exercitation ullamco
+laboris nisi ut aliquip ex ea commodo consequat.</p>
+<p>Third paragraph of the article. Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore
+eu fugiat nulla pariatur. Excepteur sint occaecat cupidatat non proident, sunt in culpa qui officia deserunt.</p>
+</article>
+<footer>Copyright footer</footer>
+</body>
+</html>`
+
+func TestMarkdown_Process(t *testing.T) {
+	t.Parallel()
+
+	cache := entity.NewCache()
+	_, err := cache.Write([]byte(articleHTML))
+	require.NoError(t, err)
+
+	page := &entity.PageBase{
+		URL:     "https://example.com/article",
+		Created: time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC),
+		Meta:    entity.Meta{Title: "Article: title"},
+	}
+
+	files, err := NewMarkdown(nil).Process(context.Background(), page, cache)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	data := string(files[0].Data)
+	assert.Equal(t, "page.md", files[0].Name)
+	assert.True(t, strings.HasPrefix(data, "---\ntitle: 'Article: title'\nurl: https://example.com/article\ncaptured: 2023-04-05T06:07:08Z\n---\n"))
+	assert.Contains(t, data, "[a relative link](https://example.com/link)")
+	assert.Contains(t, data, "Third paragraph of the article.")
+	assert.NotContains(t, data, "About")
+	assert.NotContains(t, data, "Copyright footer")
+}
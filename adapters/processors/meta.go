@@ -0,0 +1,261 @@
+package processors
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+
+	"github.com/derfenix/webarchive/entity"
+)
+
+// metaTimeLayouts are the date formats met in meta tags and JSON-LD.
+var metaTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	time.DateOnly,
+	time.RFC1123Z,
+	time.RFC1123,
+}
+
+// metaCollector collects the page meta from the document nodes. Values found first win, the JSON-LD and
+// OpenGraph values are used as fallbacks for the missing ones.
+type metaCollector struct {
+	baseURL *url.URL
+	meta    entity.Meta
+	favicon string
+	jsonLD  jsonLDMeta
+}
+
+type jsonLDMeta struct {
+	headline      string
+	author        string
+	publisher     string
+	datePublished string
+	dateModified  string
+	image         string
+}
+
+func getMetaData(document *html.Node, baseURL *url.URL) entity.Meta {
+	collector := metaCollector{
+		baseURL: baseURL,
+		meta: entity.Meta{
+			OpenGraph: make(map[string]string),
+			Twitter:   make(map[string]string),
+		},
+	}
+
+	collector.walk(document)
+
+	return collector.result()
+}
+
+func (c *metaCollector) walk(n *html.Node) {
+	for ; n != nil; n = n.NextSibling {
+		if n.Type == html.ElementNode {
+			c.element(n)
+		}
+
+		c.walk(n.FirstChild)
+	}
+}
+
+func (c *metaCollector) element(n *html.Node) {
+	attrs := make(map[string]string, len(n.Attr))
+	for _, attr := range n.Attr {
+		attrs[attr.Key] = strings.TrimSpace(attr.Val)
+	}
+
+	switch n.Data {
+	case "html":
+		setIfEmpty(&c.meta.Language, attrs["lang"])
+
+	case "title":
+		if n.FirstChild != nil && c.meta.Title == "" {
+			c.meta.Title = strings.TrimSpace(n.FirstChild.Data)
+		}
+
+	case "meta":
+		c.metaTag(attrs)
+
+	case "link":
+		for _, rel := range strings.Fields(strings.ToLower(attrs["rel"])) {
+			switch rel {
+			case "canonical":
+				setIfEmpty(&c.meta.Canonical, c.absolute(attrs["href"]))
+			case "icon":
+				setIfEmpty(&c.meta.Favicon, c.absolute(attrs["href"]))
+			case "apple-touch-icon":
+				setIfEmpty(&c.favicon, c.absolute(attrs["href"]))
+			}
+		}
+
+	case "script":
+		if strings.EqualFold(attrs["type"], "application/ld+json") && n.FirstChild != nil {
+			c.jsonLDScript(n.FirstChild.Data)
+		}
+	}
+}
+
+func (c *metaCollector) metaTag(attrs map[string]string) {
+	content := attrs["content"]
+	if content == "" {
+		return
+	}
+
+	name := strings.ToLower(attrs["name"])
+	if name == "" {
+		name = strings.ToLower(attrs["property"])
+	}
+
+	if name == "" {
+		name = strings.ToLower(attrs["itemprop"])
+	}
+
+	switch {
+	case strings.HasPrefix(name, "og:"):
+		if _, ok := c.meta.OpenGraph[name[3:]]; !ok {
+			c.meta.OpenGraph[name[3:]] = content
+		}
+
+	case strings.HasPrefix(name, "twitter:"):
+		if _, ok := c.meta.Twitter[name[8:]]; !ok {
+			c.meta.Twitter[name[8:]] = content
+		}
+	}
+
+	switch name {
+	case "description":
+		setIfEmpty(&c.meta.Description, content)
+	case "author", "article:author":
+		// article:author is often a profile URL, the plain name is preferred.
+		if !strings.Contains(content, "://") {
+			setIfEmpty(&c.meta.Author, content)
+		}
+	case "article:published_time", "datepublished", "date", "pubdate", "dc.date.issued":
+		setTimeIfEmpty(&c.meta.Published, content)
+	case "article:modified_time", "og:updated_time", "datemodified", "last-modified":
+		setTimeIfEmpty(&c.meta.Modified, content)
+	case "application-name":
+		setIfEmpty(&c.meta.SiteName, content)
+	}
+}
+
+func (c *metaCollector) jsonLDScript(data string) {
+	var value any
+	if err := json.Unmarshal([]byte(data), &value); err != nil {
+		return
+	}
+
+	c.jsonLDValue(value)
+}
+
+// jsonLDValue looks for the creative work properties in the JSON-LD objects, arrays and @graph.
+func (c *metaCollector) jsonLDValue(value any) {
+	switch typed := value.(type) {
+	case []any:
+		for _, item := range typed {
+			c.jsonLDValue(item)
+		}
+
+	case map[string]any:
+		if graph, ok := typed["@graph"]; ok {
+			c.jsonLDValue(graph)
+		}
+
+		setIfEmpty(&c.jsonLD.headline, jsonLDString(typed["headline"]))
+		setIfEmpty(&c.jsonLD.author, jsonLDString(typed["author"]))
+		setIfEmpty(&c.jsonLD.publisher, jsonLDString(typed["publisher"]))
+		setIfEmpty(&c.jsonLD.datePublished, jsonLDString(typed["datePublished"]))
+		setIfEmpty(&c.jsonLD.dateModified, jsonLDString(typed["dateModified"]))
+		setIfEmpty(&c.jsonLD.image, jsonLDString(typed["image"]))
+	}
+}
+
+// jsonLDString returns the string value, or the name or url of the object, or the first value of the array.
+func jsonLDString(value any) string {
+	switch typed := value.(type) {
+	case string:
+		return strings.TrimSpace(typed)
+	case map[string]any:
+		if name := jsonLDString(typed["name"]); name != "" {
+			return name
+		}
+
+		return jsonLDString(typed["url"])
+	case []any:
+		for _, item := range typed {
+			if result := jsonLDString(item); result != "" {
+				return result
+			}
+		}
+	}
+
+	return ""
+}
+
+func (c *metaCollector) result() entity.Meta {
+	meta := c.meta
+
+	setIfEmpty(&meta.Title, meta.OpenGraph["title"])
+	setIfEmpty(&meta.Title, meta.Twitter["title"])
+	setIfEmpty(&meta.Title, c.jsonLD.headline)
+	setIfEmpty(&meta.Description, meta.OpenGraph["description"])
+	setIfEmpty(&meta.Description, meta.Twitter["description"])
+	setIfEmpty(&meta.Author, c.jsonLD.author)
+	setIfEmpty(&meta.Author, meta.Twitter["creator"])
+	setIfEmpty(&meta.SiteName, meta.OpenGraph["site_name"])
+	setIfEmpty(&meta.SiteName, c.jsonLD.publisher)
+	setIfEmpty(&meta.Canonical, c.absolute(meta.OpenGraph["url"]))
+	setIfEmpty(&meta.Image, c.absolute(meta.OpenGraph["image"]))
+	setIfEmpty(&meta.Image, c.absolute(meta.Twitter["image"]))
+	setIfEmpty(&meta.Image, c.absolute(c.jsonLD.image))
+	setIfEmpty(&meta.Language, strings.ReplaceAll(meta.OpenGraph["locale"], "_", "-"))
+	setIfEmpty(&meta.Favicon, c.favicon)
+	setTimeIfEmpty(&meta.Published, c.jsonLD.datePublished)
+	setTimeIfEmpty(&meta.Modified, c.jsonLD.dateModified)
+
+	if meta.Favicon == "" && c.baseURL != nil {
+		meta.Favicon = c.absolute("/favicon.ico")
+	}
+
+	return meta
+}
+
+func (c *metaCollector) absolute(value string) string {
+	if value == "" || c.baseURL == nil {
+		return value
+	}
+
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return ""
+	}
+
+	return c.baseURL.ResolveReference(parsed).String()
+}
+
+func setIfEmpty(target *string, value string) {
+	if *target == "" {
+		*target = strings.TrimSpace(value)
+	}
+}
+
+func setTimeIfEmpty(target *time.Time, value string) {
+	if !target.IsZero() || value == "" {
+		return
+	}
+
+	for _, layout := range metaTimeLayouts {
+		if parsed, err := time.Parse(layout, strings.TrimSpace(value)); err == nil {
+			*target = parsed.UTC()
+
+			return
+		}
+	}
+}
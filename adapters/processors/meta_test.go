@@ -0,0 +1,84 @@
+package processors
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+)
+
+func TestGetMetaData(t *testing.T) {
+	t.Parallel()
+
+	baseURL, err := url.Parse("https://example.com/articles/1?ref=feed")
+	require.NoError(t, err)
+
+	t.Run("tags", func(t *testing.T) {
+		t.Parallel()
+
+		document, err := html.Parse(strings.NewReader(`<!DOCTYPE html>
+<html lang="en-US">
+<head>
+<title> Article title </title>
+<meta name="description" content="Article description">
+<meta name="author" content="John Doe">
+<meta property="og:title" content="OG title">
+<meta property="og:site_name" content="Example">
+<meta property="og:image" content="/images/cover.png">
+<meta name="twitter:card" content="summary">
+<meta property="article:published_time" content="2023-03-01T10:00:00+03:00">
+<meta property="article:modified_time" content="2023-03-02">
+<link rel="canonical" href="/articles/1">
+<link rel="shortcut icon" href="/static/icon.png">
+</head>
+<body></body>
+</html>`))
+		require.NoError(t, err)
+
+		meta := getMetaData(document, baseURL)
+
+		assert.Equal(t, "Article title", meta.Title)
+		assert.Equal(t, "Article description", meta.Description)
+		assert.Equal(t, "John Doe", meta.Author)
+		assert.Equal(t, "Example", meta.SiteName)
+		assert.Equal(t, "en-US", meta.Language)
+		assert.Equal(t, "https://example.com/articles/1", meta.Canonical)
+		assert.Equal(t, "https://example.com/static/icon.png", meta.Favicon)
+		assert.Equal(t, "https://example.com/images/cover.png", meta.Image)
+		assert.Equal(t, time.Date(2023, 3, 1, 7, 0, 0, 0, time.UTC), meta.Published)
+		assert.Equal(t, time.Date(2023, 3, 2, 0, 0, 0, 0, time.UTC), meta.Modified)
+		assert.Equal(t, map[string]string{"title": "OG title", "site_name": "Example", "image": "/images/cover.png"}, meta.OpenGraph)
+		assert.Equal(t, map[string]string{"card": "summary"}, meta.Twitter)
+	})
+
+	t.Run("json-ld fallback", func(t *testing.T) {
+		t.Parallel()
+
+		document, err := html.Parse(strings.NewReader(`<html><head>
+<meta property="og:description" content="OG description">
+<script type="application/ld+json">
+{"@context": "https://schema.org", "@graph": [
+  {"@type": "WebSite", "name": "Site"},
+  {"@type": "NewsArticle", "headline": "Headline", "author": [{"@type": "Person", "name": "Jane Roe"}],
+   "publisher": {"@type": "Organization", "name": "Publisher"},
+   "datePublished": "2024-01-15T08:30:00Z", "dateModified": "2024-01-16T08:30:00Z"}
+]}
+</script>
+</head><body></body></html>`))
+		require.NoError(t, err)
+
+		meta := getMetaData(document, baseURL)
+
+		assert.Equal(t, "Headline", meta.Title)
+		assert.Equal(t, "OG description", meta.Description)
+		assert.Equal(t, "Jane Roe", meta.Author)
+		assert.Equal(t, "Publisher", meta.SiteName)
+		assert.Equal(t, "https://example.com/favicon.ico", meta.Favicon)
+		assert.Equal(t, time.Date(2024, 1, 15, 8, 30, 0, 0, time.UTC), meta.Published)
+		assert.Equal(t, time.Date(2024, 1, 16, 8, 30, 0, 0, time.UTC), meta.Modified)
+	})
+}
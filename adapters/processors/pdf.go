@@ -3,6 +3,7 @@ package processors
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/SebastiaanKlippert/go-wkhtmltopdf"
@@ -11,64 +12,142 @@ import (
 	"github.com/derfenix/webarchive/entity"
 )
 
-func NewPDF(cfg config.PDF) *PDF {
-	return &PDF{cfg: cfg}
+const wkhtmltopdfBinary = "wkhtmltopdf"
+
+func NewPDF(cfg config.PDF, clientCfg config.Client, egress *EgressProxy) *PDF {
+	return &PDF{cfg: cfg, clientCfg: clientCfg, egress: egress}
 }
 
 type PDF struct {
-	cfg config.PDF
+	cfg       config.PDF
+	clientCfg config.Client
+	egress    *EgressProxy
 }
 
-func (p *PDF) Process(_ context.Context, url string, cache *entity.Cache) ([]entity.File, error) {
+func (p *PDF) Process(ctx context.Context, page *entity.PageBase, cache *entity.Cache) ([]entity.File, error) {
+	cfg := p.pageConfig(page.Options.PDF)
+
 	gen, err := wkhtmltopdf.NewPDFGenerator()
 	if err != nil {
 		return nil, fmt.Errorf("new pdf generator: %w", err)
 	}
 
-	gen.Dpi.Set(p.cfg.DPI)
-	gen.PageSize.Set(wkhtmltopdf.PageSizeA4)
+	gen.Dpi.Set(cfg.DPI)
+	gen.PageSize.Set(cfg.PageSize)
 
-	if p.cfg.Landscape {
+	if cfg.Landscape {
 		gen.Orientation.Set(wkhtmltopdf.OrientationLandscape)
 	} else {
 		gen.Orientation.Set(wkhtmltopdf.OrientationPortrait)
 	}
 
-	gen.Grayscale.Set(p.cfg.Grayscale)
-	gen.Title.Set(url)
+	gen.Grayscale.Set(cfg.Grayscale)
+	gen.Title.Set(page.URL)
 
 	opts := wkhtmltopdf.NewPageOptions()
-	opts.PrintMediaType.Set(p.cfg.MediaPrint)
+	opts.PrintMediaType.Set(cfg.MediaPrint)
 	opts.JavascriptDelay.Set(200)
 	opts.DisableJavascript.Set(false)
 	opts.LoadErrorHandling.Set("ignore")
 	opts.LoadMediaErrorHandling.Set("skip")
 	opts.FooterRight.Set("[opts]")
-	opts.HeaderLeft.Set(url)
+	opts.HeaderLeft.Set(page.URL)
 	opts.HeaderRight.Set(time.Now().Format(time.DateOnly))
 	opts.FooterFontSize.Set(10)
-	opts.Zoom.Set(p.cfg.Zoom)
-	opts.ViewportSize.Set(p.cfg.Viewport)
+	opts.Zoom.Set(cfg.Zoom)
+	opts.ViewportSize.Set(cfg.Viewport)
 	opts.NoBackground.Set(true)
-	opts.DisableLocalFileAccess.Set(false)
 	opts.DisableExternalLinks.Set(false)
 	opts.DisableInternalLinks.Set(false)
 
-	var page wkhtmltopdf.PageProvider
-	if len(cache.Get()) > 0 {
-		page = &wkhtmltopdf.PageReader{Input: cache.Reader(), PageOptions: opts}
-	} else {
-		page = &wkhtmltopdf.Page{Input: url, PageOptions: opts}
+	clientOpts, err := newWkhtmlOptions(ctx, p.clientCfg, p.egress, page.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.DisableLocalFileAccess.Set(clientOpts.disableLocalFiles)
+
+	if clientOpts.proxy != "" {
+		opts.Proxy.Set(clientOpts.proxy)
+		opts.ProxyHostnameLookup.Set(clientOpts.remoteLookup)
+	}
+
+	opts.CustomHeaderPropagation.Set(clientOpts.propagateHeaders)
+
+	if len(clientOpts.cookies) > 0 {
+		cookieJar, removeCookieJar, err := writeCookieJar(clientOpts.cookies)
+		if err != nil {
+			return nil, fmt.Errorf("write cookie jar: %w", err)
+		}
+
+		defer removeCookieJar()
+
+		gen.CookieJar.Set(cookieJar)
+	}
+
+	// Stdin is used by the arguments, so the cached document is passed in the file.
+	input := page.URL
+
+	if document := cache.Get(); len(document) > 0 {
+		documentFile, removeDocument, err := writeDocumentFile(document, page.URL, page.Meta.Encoding)
+		if err != nil {
+			return nil, fmt.Errorf("write document: %w", err)
+		}
+
+		defer removeDocument()
+
+		input = documentFile
+		opts.Allow.Set(documentFile)
 	}
 
-	gen.AddPage(page)
+	gen.AddPage(&wkhtmltopdf.Page{Input: input, PageOptions: opts})
 
-	err = gen.Create()
+	// The headers are the page options, they follow the page input in the arguments read from stdin.
+	args := gen.Args()
+	pageIndex := slices.Index(args, "page")
+
+	stdinArgs := slices.Concat(args[pageIndex:pageIndex+2], clientOpts.headerArgs(), args[pageIndex+2:])
+
+	pdf, err := runWkhtml(ctx, wkhtmltopdfBinary, args[:pageIndex], stdinArgs)
 	if err != nil {
 		return nil, fmt.Errorf("create pdf: %w", err)
 	}
 
-	file := entity.NewFile(p.cfg.Filename, gen.Bytes())
+	file := entity.NewFile(cfg.Filename, pdf)
 
 	return []entity.File{file}, nil
 }
+
+// pageConfig returns the config with values overridden by the page options.
+func (p *PDF) pageConfig(options entity.PDFOptions) config.PDF {
+	cfg := p.cfg
+
+	if options.Landscape != nil {
+		cfg.Landscape = *options.Landscape
+	}
+
+	if options.Grayscale != nil {
+		cfg.Grayscale = *options.Grayscale
+	}
+
+	if options.Zoom != 0 {
+		cfg.Zoom = options.Zoom
+	}
+
+	if options.Viewport != "" {
+		cfg.Viewport = options.Viewport
+	}
+
+	if options.PageSize != "" {
+		cfg.PageSize = options.PageSize
+	}
+
+	switch options.MediaType {
+	case entity.MediaTypePrint:
+		cfg.MediaPrint = true
+	case entity.MediaTypeScreen:
+		cfg.MediaPrint = false
+	}
+
+	return cfg
+}
@@ -0,0 +1,55 @@
+package processors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/derfenix/webarchive/config"
+	"github.com/derfenix/webarchive/entity"
+)
+
+func TestPDF_PageConfig(t *testing.T) {
+	t.Parallel()
+
+	yes, no := true, false
+
+	defaults := config.PDF{
+		Landscape:  true,
+		Grayscale:  true,
+		MediaPrint: true,
+		Zoom:       1,
+		Viewport:   "1280x720",
+		PageSize:   "A4",
+		DPI:        150,
+		Filename:   "page.pdf",
+	}
+
+	pdf := NewPDF(defaults, config.Client{}, nil)
+
+	// Unset options keep the config values.
+	assert.Equal(t, defaults, pdf.pageConfig(entity.PDFOptions{}))
+
+	// False values override the config ones.
+	cfg := pdf.pageConfig(entity.PDFOptions{Landscape: &no, Grayscale: &no, MediaType: entity.MediaTypeScreen})
+	assert.False(t, cfg.Landscape)
+	assert.False(t, cfg.Grayscale)
+	assert.False(t, cfg.MediaPrint)
+
+	cfg = NewPDF(config.PDF{Zoom: 1, Viewport: "1280x720", PageSize: "A4"}, config.Client{}, nil).pageConfig(entity.PDFOptions{
+		Landscape: &yes,
+		Grayscale: &yes,
+		Zoom:      2,
+		Viewport:  "800x600",
+		PageSize:  "Letter",
+		MediaType: entity.MediaTypePrint,
+	})
+	assert.Equal(t, config.PDF{
+		Landscape:  true,
+		Grayscale:  true,
+		MediaPrint: true,
+		Zoom:       2,
+		Viewport:   "800x600",
+		PageSize:   "Letter",
+	}, cfg)
+}
@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/derfenix/webarchive/entity"
 )
 
 func TestPDF_Process(t *testing.T) {
@@ -18,7 +20,7 @@ func TestPDF_Process(t *testing.T) {
 		t.Skip("skip test with external resource")
 	}
 
-	files, err := (&PDF{}).Process(context.Background(), "https://github.com/SebastiaanKlippert/go-wkhtmltopdf")
+	files, err := (&PDF{}).Process(context.Background(), &entity.PageBase{URL: "https://github.com/SebastiaanKlippert/go-wkhtmltopdf"}, entity.NewCache())
 	require.NoError(t, err)
 	require.Len(t, files, 1)
 
@@ -0,0 +1,386 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/derfenix/webarchive/config"
+)
+
+const (
+	robotsPath         = "/robots.txt"
+	robotsTTL          = time.Hour * 24
+	robotsTimeout      = time.Second * 10
+	robotsMaxSize      = 500 * 1024
+	robotsMaxRedirects = 5
+	initialBackoff     = time.Second
+	// backoffDrainLimit is the size of the response body read before retry, so the connection can be reused.
+	backoffDrainLimit = 64 * 1024
+	// hostIdleTTL is the time the host state is kept after its last request, the robots.txt rules of the evicted
+	// host are fetched again.
+	hostIdleTTL = time.Hour
+)
+
+// politeTransport schedules the requests to every host: it checks robots.txt rules, limits the request rate
+// and the number of simultaneous requests, and waits when the host answers with 429 or 503 status.
+type politeTransport struct {
+	base    http.RoundTripper
+	cfg     config.Politeness
+	headers http.Header
+
+	mu          sync.Mutex
+	hosts       map[string]*politeHost
+	lastEvicted time.Time
+}
+
+func newPoliteTransport(base http.RoundTripper, cfg config.Politeness, headers http.Header) *politeTransport {
+	return &politeTransport{
+		base:    base,
+		cfg:     cfg,
+		headers: headers,
+		hosts:   make(map[string]*politeHost),
+	}
+}
+
+type politeHost struct {
+	// slots limits the simultaneous requests, nil means no limit.
+	slots chan struct{}
+
+	// active and used are guarded by the transport mutex.
+	active int
+	used   time.Time
+
+	mu          sync.Mutex
+	next        time.Time
+	pausedUntil time.Time
+
+	robotsMu      sync.Mutex
+	robots        *robotsRules
+	robotsFetched time.Time
+}
+
+func (t *politeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := t.host(req.URL.Host)
+
+	response, err := t.roundTrip(req, host)
+	if err != nil {
+		t.done(host)
+
+		return nil, err
+	}
+
+	response.Body = &releaseBody{ReadCloser: response.Body, release: func() {
+		host.release()
+		t.done(host)
+	}}
+
+	return response, nil
+}
+
+// roundTrip sends the request, the returned response holds the host slot.
+func (t *politeTransport) roundTrip(req *http.Request, host *politeHost) (*http.Response, error) {
+	var crawlDelay time.Duration
+
+	if t.cfg.RobotsTxt && req.URL.Path != robotsPath {
+		rules := host.robotsRules(req, t.fetchRobots)
+
+		if !rules.allowed(req.URL.RequestURI()) {
+			return nil, fmt.Errorf("%s is disallowed by robots.txt", req.URL)
+		}
+
+		crawlDelay = rules.crawlDelay
+	}
+
+	interval := crawlDelay
+	if t.cfg.HostRate > 0 {
+		interval = max(interval, time.Duration(float64(time.Second)/t.cfg.HostRate))
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := host.acquire(req.Context()); err != nil {
+			return nil, err
+		}
+
+		if err := host.wait(req.Context(), interval); err != nil {
+			host.release()
+
+			return nil, err
+		}
+
+		response, err := t.base.RoundTrip(req)
+		if err != nil {
+			host.release()
+
+			return nil, err
+		}
+
+		delay, retry := t.backoff(req, response, attempt)
+		if delay > 0 {
+			host.pause(delay)
+		}
+
+		if !retry {
+			return response, nil
+		}
+
+		_, _ = io.CopyN(io.Discard, response.Body, backoffDrainLimit)
+		_ = response.Body.Close()
+
+		host.release()
+	}
+}
+
+// backoff returns the host pause and whether the request is retried after it, if the response asks to slow down.
+// The delay advertised by Retry-After pauses the host even if the request is not retried.
+func (t *politeTransport) backoff(req *http.Request, response *http.Response, attempt int) (time.Duration, bool) {
+	if response.StatusCode != http.StatusTooManyRequests && response.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+
+	advertised, ok := parseRetryAfter(response.Header.Get("Retry-After"), time.Now())
+
+	delay := advertised
+	if !ok {
+		delay = initialBackoff << attempt
+	}
+
+	// Only the requests without body can be repeated.
+	if attempt >= t.cfg.BackoffRetries || req.Body != nil && req.Body != http.NoBody || delay > t.cfg.MaxBackoff {
+		return advertised, false
+	}
+
+	return delay, true
+}
+
+// host returns the host state and marks it active until done is called.
+func (t *politeTransport) host(name string) *politeHost {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+
+	if now.Sub(t.lastEvicted) > hostIdleTTL {
+		t.evict(now)
+	}
+
+	host, ok := t.hosts[name]
+	if !ok {
+		host = &politeHost{}
+
+		if t.cfg.HostConcurrency > 0 {
+			host.slots = make(chan struct{}, t.cfg.HostConcurrency)
+		}
+
+		t.hosts[name] = host
+	}
+
+	host.active++
+	host.used = now
+
+	return host
+}
+
+func (t *politeTransport) done(host *politeHost) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	host.active--
+	host.used = time.Now()
+}
+
+// evict removes the hosts without active requests, not used for hostIdleTTL and not paused.
+func (t *politeTransport) evict(now time.Time) {
+	t.lastEvicted = now
+
+	for name, host := range t.hosts {
+		if host.active == 0 && now.Sub(host.used) > hostIdleTTL && !host.busy(now) {
+			delete(t.hosts, name)
+		}
+	}
+}
+
+// fetchRobots returns the robots.txt rules of the request host. Missing or unavailable robots.txt allows all.
+func (t *politeTransport) fetchRobots(req *http.Request) *robotsRules {
+	ctx, cancel := context.WithTimeout(req.Context(), robotsTimeout)
+	defer cancel()
+
+	robotsURL := *req.URL
+	robotsURL.Path = robotsPath
+	robotsURL.RawPath = ""
+	robotsURL.RawQuery = ""
+	robotsURL.Fragment = ""
+
+	robotsReq, err := http.NewRequestWithContext(ctx, http.MethodGet, robotsURL.String(), nil)
+	if err != nil {
+		return &robotsRules{}
+	}
+
+	robotsReq.Header = t.headers.Clone()
+
+	client := &http.Client{
+		Transport: t.base,
+		CheckRedirect: func(_ *http.Request, via []*http.Request) error {
+			if len(via) >= robotsMaxRedirects {
+				return http.ErrUseLastResponse
+			}
+
+			return nil
+		},
+	}
+
+	response, err := client.Do(robotsReq)
+	if err != nil {
+		return &robotsRules{}
+	}
+
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	if response.StatusCode != http.StatusOK {
+		return &robotsRules{}
+	}
+
+	data, err := io.ReadAll(io.LimitReader(response.Body, robotsMaxSize))
+	if err != nil {
+		return &robotsRules{}
+	}
+
+	return parseRobots(data, t.cfg.RobotsUserAgent)
+}
+
+func (h *politeHost) robotsRules(req *http.Request, fetch func(*http.Request) *robotsRules) *robotsRules {
+	h.robotsMu.Lock()
+	defer h.robotsMu.Unlock()
+
+	if h.robots == nil || time.Since(h.robotsFetched) > robotsTTL {
+		h.robots = fetch(req)
+		h.robotsFetched = time.Now()
+	}
+
+	return h.robots
+}
+
+func (h *politeHost) acquire(ctx context.Context) error {
+	if h.slots == nil {
+		return nil
+	}
+
+	select {
+	case h.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (h *politeHost) release() {
+	if h.slots != nil {
+		<-h.slots
+	}
+}
+
+// wait reserves the request time respecting the interval between requests and the host pause, and waits for it.
+// The request which would wait past its deadline fails at once, its error is not transient, as the host
+// paused for Retry-After longer than the request timeout fails the retries too.
+func (h *politeHost) wait(ctx context.Context, interval time.Duration) error {
+	h.mu.Lock()
+
+	now := time.Now()
+
+	slot := now
+	if h.next.After(slot) {
+		slot = h.next
+	}
+
+	if h.pausedUntil.After(slot) {
+		slot = h.pausedUntil
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && slot.After(deadline) {
+		h.mu.Unlock()
+
+		return fmt.Errorf("host is busy until %s, after the request deadline", slot.Format(time.RFC3339))
+	}
+
+	h.next = slot.Add(interval)
+
+	h.mu.Unlock()
+
+	if !slot.After(now) {
+		return nil
+	}
+
+	timer := time.NewTimer(slot.Sub(now))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// busy reports whether the host is paused or the next request time is reserved.
+func (h *politeHost) busy(now time.Time) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.pausedUntil.After(now) || h.next.After(now)
+}
+
+func (h *politeHost) pause(delay time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if until := time.Now().Add(delay); until.After(h.pausedUntil) {
+		h.pausedUntil = until
+	}
+}
+
+// releaseBody releases the host slot when the response body is read to the end or closed, the connection is busy
+// until then. The processors may request the page resources before the page body is closed.
+type releaseBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releaseBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil {
+		b.once.Do(b.release)
+	}
+
+	return n, err
+}
+
+func (b *releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+
+	return err
+}
+
+// parseRetryAfter parses the Retry-After header value, which is the delay in seconds or the HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,307 @@
+package processors
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/derfenix/webarchive/config"
+)
+
+func TestParseRobots(t *testing.T) {
+	t.Parallel()
+
+	robots := []byte(`# comment
+User-agent: *
+Disallow: /private/
+Allow: /private/public$
+Crawl-delay: 1
+
+User-agent: Googlebot
+User-agent: webarchive
+Disallow: /archive   # no archiving
+Allow: /archive/*.html$
+Disallow: /*.pdf$
+Crawl-delay: 0.5
+`)
+
+	rules := parseRobots(robots, "webarchive")
+	assert.Equal(t, 500*time.Millisecond, rules.crawlDelay)
+	assert.True(t, rules.allowed("/private/page"), "* group is not used")
+	assert.False(t, rules.allowed("/archive"))
+	assert.False(t, rules.allowed("/archive/list?page=2"))
+	assert.True(t, rules.allowed("/archive/2023/page.html"))
+	assert.False(t, rules.allowed("/files/doc.pdf"))
+	assert.True(t, rules.allowed("/files/doc.pdf?download"))
+	assert.True(t, rules.allowed("/robots.txt"))
+
+	rules = parseRobots(robots, "otherbot")
+	assert.Equal(t, time.Second, rules.crawlDelay)
+	assert.False(t, rules.allowed("/private/page"))
+	assert.True(t, rules.allowed("/private/public"))
+	assert.True(t, rules.allowed("/archive"))
+
+	assert.True(t, parseRobots(nil, "webarchive").allowed("/any"))
+
+	allowOnly := []byte(`User-agent: webarchive
+Disallow:
+
+User-agent: *
+Disallow: /
+`)
+
+	assert.True(t, parseRobots(allowOnly, "webarchive").allowed("/page"), "empty matching group allows all")
+	assert.False(t, parseRobots(allowOnly, "otherbot").allowed("/page"))
+
+	prefix := []byte(`User-agent: web
+Disallow: /
+
+User-agent: *
+Disallow: /private/
+`)
+
+	rules = parseRobots(prefix, "WebArchive")
+	assert.True(t, rules.allowed("/page"), "prefix group is not used")
+	assert.False(t, rules.allowed("/private/page"))
+
+	assert.False(t, parseRobots(prefix, "WEB").allowed("/page"), "product token is case-insensitive")
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	delay, ok := parseRetryAfter("120", now)
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Minute, delay)
+
+	delay, ok = parseRetryAfter("Mon, 01 Jan 2024 00:00:30 GMT", now)
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, delay)
+
+	_, ok = parseRetryAfter("soon", now)
+	assert.False(t, ok)
+}
+
+func TestPoliteTransport(t *testing.T) {
+	t.Parallel()
+
+	t.Run("robots", func(t *testing.T) {
+		t.Parallel()
+
+		var robotsRequests atomic.Int32
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+			robotsRequests.Add(1)
+			assert.Equal(t, "test-agent", r.UserAgent())
+			_, _ = w.Write([]byte("User-agent: *\nDisallow: /private\n"))
+		})
+		mux.HandleFunc("/", func(http.ResponseWriter, *http.Request) {})
+
+		server := httptest.NewServer(mux)
+		t.Cleanup(server.Close)
+
+		client, err := NewHTTPClient(
+			config.Client{UserAgent: "test-agent", MaxRedirects: 10},
+			config.Politeness{RobotsTxt: true, RobotsUserAgent: "webarchive"},
+			config.Egress{},
+		)
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			response, err := client.Get(server.URL + "/page")
+			require.NoError(t, err)
+			require.NoError(t, response.Body.Close())
+		}
+
+		_, err = client.Get(server.URL + "/private/page")
+		assert.ErrorContains(t, err, "disallowed by robots.txt")
+		assert.Equal(t, int32(1), robotsRequests.Load())
+	})
+
+	t.Run("concurrency and rate", func(t *testing.T) {
+		t.Parallel()
+
+		var active, maxActive atomic.Int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			current := active.Add(1)
+			defer active.Add(-1)
+
+			for {
+				observed := maxActive.Load()
+				if current <= observed || maxActive.CompareAndSwap(observed, current) {
+					break
+				}
+			}
+
+			time.Sleep(20 * time.Millisecond)
+		}))
+		t.Cleanup(server.Close)
+
+		client, err := NewHTTPClient(config.Client{MaxRedirects: 10}, config.Politeness{HostConcurrency: 2, HostRate: 100}, config.Egress{})
+		require.NoError(t, err)
+
+		start := time.Now()
+		done := make(chan struct{})
+
+		for i := 0; i < 6; i++ {
+			go func() {
+				defer func() { done <- struct{}{} }()
+
+				response, err := client.Get(server.URL)
+				if assert.NoError(t, err) {
+					assert.NoError(t, response.Body.Close())
+				}
+			}()
+		}
+
+		for i := 0; i < 6; i++ {
+			<-done
+		}
+
+		assert.Equal(t, int32(2), maxActive.Load())
+		assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond, "6 requests with 10ms interval")
+	})
+
+	t.Run("backoff", func(t *testing.T) {
+		t.Parallel()
+
+		var requests atomic.Int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			if requests.Add(1) < 3 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+
+				return
+			}
+
+			_, _ = w.Write([]byte("ok"))
+		}))
+		t.Cleanup(server.Close)
+
+		client, err := NewHTTPClient(config.Client{MaxRedirects: 10}, config.Politeness{BackoffRetries: 3, MaxBackoff: time.Second}, config.Egress{})
+		require.NoError(t, err)
+
+		response, err := client.Get(server.URL)
+		require.NoError(t, err)
+		require.NoError(t, response.Body.Close())
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, int32(3), requests.Load())
+
+		client, err = NewHTTPClient(config.Client{MaxRedirects: 10}, config.Politeness{BackoffRetries: 1, MaxBackoff: time.Second}, config.Egress{})
+		require.NoError(t, err)
+
+		requests.Store(0)
+
+		response, err = client.Get(server.URL)
+		require.NoError(t, err)
+		require.NoError(t, response.Body.Close())
+		assert.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+	})
+
+	t.Run("long retry after", func(t *testing.T) {
+		t.Parallel()
+
+		var requests atomic.Int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			requests.Add(1)
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		t.Cleanup(server.Close)
+
+		transport := newPoliteTransport(http.DefaultTransport, config.Politeness{BackoffRetries: 3, MaxBackoff: time.Second}, nil)
+
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+
+		response, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+		require.NoError(t, response.Body.Close())
+		assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+		assert.Equal(t, int32(1), requests.Load())
+
+		host := transport.hosts[req.URL.Host]
+		assert.WithinDuration(t, time.Now().Add(time.Hour), host.pausedUntil, time.Minute)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+
+		_, err = transport.RoundTrip(req.WithContext(ctx))
+		assert.ErrorContains(t, err, "after the request deadline")
+		assert.False(t, isTransient(err))
+		assert.Less(t, time.Since(start), 50*time.Millisecond, "fails without waiting")
+		assert.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("slot released on body end", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte("ok"))
+		}))
+		t.Cleanup(server.Close)
+
+		client, err := NewHTTPClient(config.Client{MaxRedirects: 10}, config.Politeness{HostConcurrency: 1}, config.Egress{})
+		require.NoError(t, err)
+
+		page, err := client.Get(server.URL)
+		require.NoError(t, err)
+
+		defer func() {
+			_ = page.Body.Close()
+		}()
+
+		body, err := io.ReadAll(page.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "ok", string(body))
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/style.css", nil)
+		require.NoError(t, err)
+
+		resource, err := client.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resource.Body.Close())
+	})
+
+	t.Run("evict idle hosts", func(t *testing.T) {
+		t.Parallel()
+
+		transport := newPoliteTransport(http.DefaultTransport, config.Politeness{}, nil)
+
+		idle := transport.host("idle.example.com")
+		transport.done(idle)
+
+		active := transport.host("active.example.com")
+
+		paused := transport.host("paused.example.com")
+		transport.done(paused)
+		paused.pause(time.Hour * 2)
+
+		old := time.Now().Add(-hostIdleTTL * 2)
+		idle.used, active.used, paused.used = old, old, old
+
+		transport.evict(time.Now())
+
+		assert.NotContains(t, transport.hosts, "idle.example.com")
+		assert.Contains(t, transport.hosts, "active.example.com")
+		assert.Contains(t, transport.hosts, "paused.example.com")
+	})
+}
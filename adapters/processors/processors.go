@@ -1,81 +1,119 @@
 package processors
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net"
 	"net/http"
-	"net/http/cookiejar"
-	"strings"
-	"time"
+	"net/url"
+	"path"
+	"syscall"
 
+	"github.com/gabriel-vasile/mimetype"
 	"go.uber.org/zap"
 	"golang.org/x/net/html"
 
+	"github.com/derfenix/webarchive/adapters/processors/internal"
 	"github.com/derfenix/webarchive/config"
 	"github.com/derfenix/webarchive/entity"
 )
 
-const defaultEncoding = "utf-8"
+// sniffLen is the size of the response body start used to detect its type without the Content-Type header.
+const sniffLen = 512
 
 type processor interface {
-	Process(ctx context.Context, url string, cache *entity.Cache) ([]entity.File, error)
+	Process(ctx context.Context, page *entity.PageBase, cache *entity.Cache) ([]entity.File, error)
 }
 
 func NewProcessors(cfg config.Config, log *zap.Logger) (*Processors, error) {
-	jar, err := cookiejar.New(&cookiejar.Options{
-		PublicSuffixList: nil,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("create cookie jar: %w", err)
-	}
-
-	httpClient := &http.Client{
-		Transport: &http.Transport{
-			DialContext: (&net.Dialer{
-				Timeout:   time.Second * 10,
-				KeepAlive: time.Second * 10,
-			}).DialContext,
-			MaxIdleConns:           20,
-			MaxIdleConnsPerHost:    5,
-			MaxConnsPerHost:        10,
-			IdleConnTimeout:        time.Second * 60,
-			ResponseHeaderTimeout:  time.Second * 20,
-			MaxResponseHeaderBytes: 1024 * 1024 * 50,
-			WriteBufferSize:        256,
-			ReadBufferSize:         1024 * 64,
-			ForceAttemptHTTP2:      true,
-		},
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			if len(via) > 3 {
-				return fmt.Errorf("too many redirects")
-			}
+	httpClient, err := NewHTTPClient(cfg.Client, cfg.Politeness, cfg.Egress)
+	if err != nil {
+		return nil, fmt.Errorf("new http client: %w", err)
+	}
 
-			return nil
-		},
-		Jar:     jar,
-		Timeout: time.Second * 30,
+	egressProxy, err := NewEgressProxy(cfg.Client, cfg.Egress)
+	if err != nil {
+		return nil, fmt.Errorf("new egress proxy: %w", err)
+	}
+
+	singleFile, err := NewSingleFile(cfg.SingleFile, cfg.Inline, httpClient, log)
+	if err != nil {
+		return nil, fmt.Errorf("new single file processor: %w", err)
+	}
+
+	headers, err := NewHeaders(cfg.Headers, httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("new headers processor: %w", err)
+	}
+
+	screenshot, err := NewScreenshot(cfg.Screenshot, cfg.Client, egressProxy)
+	if err != nil {
+		return nil, fmt.Errorf("new screenshot processor: %w", err)
 	}
 
 	procs := Processors{
 		client: httpClient,
 		processors: map[entity.Format]processor{
-			entity.FormatHeaders:    NewHeaders(httpClient),
-			entity.FormatPDF:        NewPDF(cfg.PDF),
-			entity.FormatSingleFile: NewSingleFile(httpClient, log),
+			entity.FormatHeaders:    headers,
+			entity.FormatPDF:        NewPDF(cfg.PDF, cfg.Client, egressProxy),
+			entity.FormatSingleFile: singleFile,
+			entity.FormatWARC:       NewWARC(cfg.Inline, httpClient, log),
+			entity.FormatMarkdown:   NewMarkdown(httpClient),
+			entity.FormatHTMLBundle: NewHTMLBundle(cfg.Inline, httpClient, log),
+			entity.FormatScreenshot: screenshot,
+			entity.FormatText:       NewText(httpClient),
+			entity.FormatEPUB:       NewEPUB(cfg.Inline, httpClient, log),
+			entity.FormatRaw:        NewRaw(httpClient),
 		},
 	}
 
+	for _, externalFormat := range cfg.ExternalFormats {
+		format, err := entity.RegisterExternalFormat(externalFormat.ID, externalFormat.Name)
+		if err != nil {
+			return nil, fmt.Errorf("register external format: %w", err)
+		}
+
+		if err := procs.OverrideProcessor(format, NewExternal(externalFormat, httpClient, egressProxy)); err != nil {
+			return nil, fmt.Errorf("override processor for external format %s: %w", externalFormat.Name, err)
+		}
+	}
+
 	return &procs, nil
 }
 
 type Processors struct {
 	processors map[entity.Format]processor
 	client     *http.Client
+	profiles   Profiles
+}
+
+// WithProfiles enables the credential profiles applied to the requests to the matching hosts.
+func (p *Processors) WithProfiles(profiles Profiles) *Processors {
+	p.profiles = profiles
+
+	return p
+}
+
+// profilesContext adds the profiles to the context, they are applied by the client transport and wkhtmltopdf.
+func (p *Processors) profilesContext(ctx context.Context) (context.Context, error) {
+	if p.profiles == nil {
+		return ctx, nil
+	}
+
+	profiles, err := p.profiles.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list profiles: %w", err)
+	}
+
+	return withProfiles(ctx, profiles), nil
 }
 
-func (p *Processors) Process(ctx context.Context, format entity.Format, url string, cache *entity.Cache) entity.Result {
+func (p *Processors) Process(ctx context.Context, format entity.Format, page *entity.PageBase, cache *entity.Cache) entity.Result {
 	result := entity.Result{Format: format}
 
 	proc, ok := p.processors[format]
@@ -85,9 +123,19 @@ func (p *Processors) Process(ctx context.Context, format entity.Format, url stri
 		return result
 	}
 
-	files, err := proc.Process(ctx, url, cache)
+	ctx, err := p.profilesContext(ctx)
+	if err != nil {
+		result.Err = err
+
+		return result
+	}
+
+	files, err := proc.Process(ctx, page, cache)
 	if err != nil {
 		result.Err = fmt.Errorf("process: %w", err)
+		if isTransient(err) {
+			result.Err = entity.NewTransientError(result.Err)
+		}
 
 		return result
 	}
@@ -104,6 +152,11 @@ func (p *Processors) OverrideProcessor(format entity.Format, proc processor) err
 }
 
 func (p *Processors) GetMeta(ctx context.Context, url string, cache *entity.Cache) (entity.Meta, error) {
+	ctx, err := p.profilesContext(ctx)
+	if err != nil {
+		return entity.Meta{}, err
+	}
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return entity.Meta{}, fmt.Errorf("new request: %w", err)
@@ -114,10 +167,6 @@ func (p *Processors) GetMeta(ctx context.Context, url string, cache *entity.Cach
 		return entity.Meta{}, fmt.Errorf("do request: %w", err)
 	}
 
-	if response.StatusCode != http.StatusOK {
-		return entity.Meta{}, fmt.Errorf("want status 200, got %d", response.StatusCode)
-	}
-
 	if response.Body == nil {
 		return entity.Meta{}, fmt.Errorf("empty response body")
 	}
@@ -126,78 +175,251 @@ func (p *Processors) GetMeta(ctx context.Context, url string, cache *entity.Cach
 		_ = response.Body.Close()
 	}()
 
-	tee := io.TeeReader(response.Body, cache)
+	if response.StatusCode != http.StatusOK {
+		return entity.Meta{}, &statusError{code: response.StatusCode}
+	}
+
+	body := bufio.NewReader(response.Body)
+
+	contentType := response.Header.Get("Content-Type")
+	if contentType == "" {
+		head, _ := body.Peek(sniffLen)
+		contentType = mimetype.Detect(head).String()
+	}
+
+	// Other content is saved as is, so it is not read here, see entity.Page.Process.
+	if !entity.IsHTMLContentType(contentType) {
+		return fileMeta(response, contentType), nil
+	}
+
+	// The cache keeps the document as is, its consumers decode it with the detected encoding, see decodeDocument.
+	tee := io.TeeReader(body, cache)
 
-	htmlNode, err := html.Parse(tee)
+	decoded, encoding, err := internal.DecodeHTML(tee, response.Header.Get("Content-Type"))
+	if err != nil {
+		return entity.Meta{}, fmt.Errorf("decode response body: %w", err)
+	}
+
+	htmlNode, err := html.Parse(decoded)
 	if err != nil {
 		return entity.Meta{}, fmt.Errorf("parse response body: %w", err)
 	}
 
-	var fc *html.Node
-	for fc = htmlNode.FirstChild; fc != nil && fc.Data != "html"; fc = fc.NextSibling {
+	cache.SetDocumentResponse(response.Request.URL.String(), response.Header.Clone())
+
+	meta := getMetaData(htmlNode, response.Request.URL)
+	meta.Encoding = encoding
+
+	return meta, nil
+}
+
+// fileMeta makes the meta of the non-HTML page, its title is the file name.
+func fileMeta(response *http.Response, contentType string) entity.Meta {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = "application/octet-stream"
 	}
 
-	if fc == nil {
-		return entity.Meta{}, fmt.Errorf("failed to find html tag")
+	meta := entity.Meta{ContentType: mediaType}
+
+	if _, params, err := mime.ParseMediaType(response.Header.Get("Content-Disposition")); err == nil {
+		meta.Title = params["filename"]
 	}
 
-	fc = fc.NextSibling
-	if fc == nil {
-		return entity.Meta{}, fmt.Errorf("failed to find html tag")
+	if meta.Title == "" {
+		meta.Title = path.Base(response.Request.URL.Path)
+
+		if unescaped, err := url.PathUnescape(meta.Title); err == nil {
+			meta.Title = unescaped
+		}
 	}
 
-	for fc = fc.FirstChild; fc != nil && fc.Data != "head"; fc = fc.NextSibling {
-		fmt.Println(fc.Data)
+	if meta.Title == "/" || meta.Title == "." {
+		meta.Title = response.Request.URL.Host
 	}
 
-	if fc == nil {
-		return entity.Meta{}, fmt.Errorf("failed to find html tag")
+	return meta
+}
+
+// pageDocument returns the page document decoded to UTF-8, see cachedDocument.
+func pageDocument(ctx context.Context, client *http.Client, page *entity.PageBase, cache *entity.Cache) (io.Reader, error) {
+	document, err := cachedDocument(ctx, client, page, cache)
+	if err != nil {
+		return nil, err
 	}
 
-	meta := entity.Meta{}
-	getMetaData(fc, &meta)
-	meta.Encoding = encodingFromHeader(response.Header)
+	return decodeDocument(page, bytes.NewReader(document.Data), http.Header(document.Header).Get("Content-Type"))
+}
 
-	return meta, nil
+// cachedDocument returns the page document from the cache, or requests and caches it, so all formats of the page
+// use the same document. The response body is read and closed before the document is processed, so the host slot
+// is free for the resource requests.
+func cachedDocument(ctx context.Context, client *http.Client, page *entity.PageBase, cache *entity.Cache) (entity.CachedResource, error) {
+	if document, ok := cache.Document(); ok {
+		return document, nil
+	}
+
+	response, err := get(ctx, client, page.URL)
+	if err != nil {
+		return entity.CachedResource{}, err
+	}
+
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return entity.CachedResource{}, fmt.Errorf("read response body: %w", err)
+	}
+
+	return cache.SetDocument(cachedResource(response, data)), nil
 }
 
-func getMetaData(n *html.Node, meta *entity.Meta) {
-	if n == nil {
-		return
+func cachedResource(response *http.Response, data []byte) entity.CachedResource {
+	return entity.CachedResource{
+		MimeType: response.Header.Get("Content-Type"),
+		FinalURL: response.Request.URL.String(),
+		Header:   response.Header.Clone(),
+		Data:     data,
 	}
+}
 
-	for c := n.FirstChild; c != nil; c = c.NextSibling {
-		if c.Type == html.ElementNode && c.Data == "title" {
-			meta.Title = c.FirstChild.Data
-		}
-		if c.Type == html.ElementNode && c.Data == "meta" {
-			attrs := make(map[string]string)
-			for _, attr := range c.Attr {
-				attrs[attr.Key] = attr.Val
-			}
-
-			name, ok := attrs["name"]
-			if ok && name == "description" {
-				meta.Description = attrs["content"]
-			}
+// cachedHeader returns the cached response header, the resources cached without it get the header with
+// their mime type only.
+func cachedHeader(resource entity.CachedResource) http.Header {
+	header := http.Header(resource.Header).Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+
+	if header.Get("Content-Type") == "" && resource.MimeType != "" {
+		header.Set("Content-Type", resource.MimeType)
+	}
+
+	return header
+}
+
+// decodeDocument transcodes the page document to UTF-8. The contentType is the page response Content-Type,
+// or empty for the cached document, which is decoded with the encoding detected by GetMeta.
+func decodeDocument(page *entity.PageBase, reader io.Reader, contentType string) (io.Reader, error) {
+	if contentType == "" && page.Meta.Encoding != "" {
+		contentType = "text/html; charset=" + page.Meta.Encoding
+	}
+
+	decoded, _, err := internal.DecodeHTML(reader, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("decode document: %w", err)
+	}
+
+	return decoded, nil
+}
+
+func get(ctx context.Context, client *http.Client, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("new request: %w", err)
+	}
+
+	response, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("do request: %w", err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		if response.Body != nil {
+			_ = response.Body.Close()
 		}
 
-		getMetaData(c, meta)
+		return nil, &statusError{code: response.StatusCode}
+	}
+
+	if response.Body == nil {
+		return nil, fmt.Errorf("empty response body")
+	}
+
+	return response, nil
+}
+
+// statusError is the unexpected response status.
+type statusError struct {
+	code int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("want status 200, got %d", e.code)
+}
+
+// isTransient reports whether the error may disappear on retry: timeouts, connection failures,
+// 5xx and 429 responses.
+func isTransient(err error) bool {
+	var status *statusError
+	if errors.As(err, &status) {
+		return status.code == http.StatusTooManyRequests || status.code >= http.StatusInternalServerError
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTimeout || dnsErr.IsTemporary
 	}
+
+	var netErr net.Error
+
+	return errors.As(err, &netErr) && netErr.Timeout()
 }
 
-func encodingFromHeader(headers http.Header) string {
-	var foundEncoding bool
-	var encoding string
+// cachedGet returns the resource from the page cache, or downloads and caches it, so all formats of the page
+// use the same resources content.
+func cachedGet(ctx context.Context, client *http.Client, cache *entity.Cache, resourceURL string) (*http.Response, error) {
+	if cache == nil {
+		return get(ctx, client, resourceURL)
+	}
+
+	resource, ok := cache.Resource(resourceURL)
+	if !ok {
+		response, err := get(ctx, client, resourceURL)
+		if err != nil {
+			return nil, err
+		}
+
+		defer func() {
+			_ = response.Body.Close()
+		}()
 
-	_, encoding, foundEncoding = strings.Cut(headers.Get("Content-Type"), "; ")
-	if foundEncoding {
-		_, encoding, foundEncoding = strings.Cut(encoding, "=")
+		data, err := io.ReadAll(response.Body)
+		if err != nil {
+			return nil, fmt.Errorf("read response body: %w", err)
+		}
+
+		resource = cachedResource(response, data)
+
+		cache.SetResource(resourceURL, resource)
 	}
 
-	if !foundEncoding {
-		encoding = defaultEncoding
+	finalURL, err := url.Parse(resource.FinalURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse cached resource url: %w", err)
 	}
 
-	return encoding
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Header:        cachedHeader(resource),
+		Body:          io.NopCloser(bytes.NewReader(resource.Data)),
+		ContentLength: int64(len(resource.Data)),
+		Request:       &http.Request{Method: http.MethodGet, URL: finalURL},
+	}, nil
 }
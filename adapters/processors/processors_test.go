@@ -2,11 +2,16 @@ package processors
 
 import (
 	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
+	"golang.org/x/text/encoding/charmap"
 
 	"github.com/derfenix/webarchive/config"
 	"github.com/derfenix/webarchive/entity"
@@ -28,3 +33,194 @@ func TestProcessors_GetMeta(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "Сколько стоит умный дом? Рассказываю, как строил свой и что получилось за 1000 руб./м² / Хабр", meta.Title)
 }
+
+func TestCachedGet(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old.css", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/style.css", http.StatusFound)
+	})
+	mux.HandleFunc("/style.css", func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "text/css")
+		_, _ = w.Write([]byte("body {}"))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	ctx := context.Background()
+	cache := entity.NewCache()
+
+	for i := 0; i < 2; i++ {
+		response, err := cachedGet(ctx, server.Client(), cache, server.URL+"/old.css")
+		require.NoError(t, err)
+
+		data, err := io.ReadAll(response.Body)
+		require.NoError(t, err)
+
+		assert.Equal(t, "body {}", string(data))
+		assert.Equal(t, "text/css", response.Header.Get("Content-Type"))
+		assert.Equal(t, server.URL+"/style.css", response.Request.URL.String())
+	}
+
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestPageDocument(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(`<html><body>page</body></html>`))
+	}))
+	t.Cleanup(server.Close)
+
+	ctx := context.Background()
+	cache := entity.NewCache()
+	page := &entity.PageBase{URL: server.URL}
+
+	for i := 0; i < 2; i++ {
+		reader, err := pageDocument(ctx, server.Client(), page, cache)
+		require.NoError(t, err)
+
+		data, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, `<html><body>page</body></html>`, string(data))
+	}
+
+	assert.Equal(t, int32(1), requests.Load())
+
+	document, ok := cache.Document()
+	require.True(t, ok)
+	assert.Equal(t, server.URL, document.FinalURL)
+	assert.Equal(t, "text/html; charset=utf-8", document.MimeType)
+}
+
+func TestProcessors_Charset(t *testing.T) {
+	t.Parallel()
+
+	document, err := charmap.Windows1251.NewEncoder().String(`<html><head>
+<meta http-equiv="Content-Type" content="text/html; charset=windows-1251">
+<title>Заголовок</title>
+</head><body><p>Текст страницы</p></body></html>`)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(document))
+	}))
+	t.Cleanup(server.Close)
+
+	ctx := context.Background()
+	cfg, err := config.NewConfig(ctx)
+	require.NoError(t, err)
+
+	cfg.Egress.AllowedNetworks = []string{"127.0.0.0/8"}
+
+	procs, err := NewProcessors(cfg, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	cache := entity.NewCache()
+
+	meta, err := procs.GetMeta(ctx, server.URL, cache)
+	require.NoError(t, err)
+	assert.Equal(t, "Заголовок", meta.Title)
+	assert.Equal(t, "windows-1251", meta.Encoding)
+	assert.Equal(t, document, string(cache.Get()), "cache keeps the original document")
+
+	singleFile, err := NewSingleFile(cfg.SingleFile, cfg.Inline, server.Client(), zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	page := &entity.PageBase{URL: server.URL, Meta: meta}
+
+	files, err := singleFile.Process(ctx, page, cache)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	result := string(files[0].Data)
+	assert.Contains(t, result, "<title>Заголовок</title>")
+	assert.Contains(t, result, "<p>Текст страницы</p>")
+	assert.Contains(t, result, `content="text/html; charset=utf-8"`)
+}
+
+func TestProcessors_GetMetaFile(t *testing.T) {
+	t.Parallel()
+
+	pdf := []byte("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n1 0 obj\n<<>>\nendobj\ntrailer\n<<>>\n%%EOF\n")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/papers/datasheet%20v2.pdf", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		_, _ = w.Write(pdf)
+	})
+	mux.HandleFunc("/download", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Disposition", `attachment; filename="report.pdf"`)
+		_, _ = w.Write(pdf)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	ctx := context.Background()
+	cfg, err := config.NewConfig(ctx)
+	require.NoError(t, err)
+
+	cfg.Egress.AllowedNetworks = []string{"127.0.0.0/8"}
+
+	procs, err := NewProcessors(cfg, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	cache := entity.NewCache()
+
+	meta, err := procs.GetMeta(ctx, server.URL+"/papers/datasheet%20v2.pdf", cache)
+	require.NoError(t, err)
+	assert.Equal(t, "datasheet v2.pdf", meta.Title)
+	assert.Equal(t, "application/pdf", meta.ContentType)
+	assert.False(t, meta.IsHTML())
+	assert.Empty(t, cache.Get())
+
+	meta, err = procs.GetMeta(ctx, server.URL+"/download", cache)
+	require.NoError(t, err)
+	assert.Equal(t, "report.pdf", meta.Title)
+	assert.Equal(t, "application/pdf", meta.ContentType)
+}
+
+func TestProcessors_ProcessTransient(t *testing.T) {
+	t.Parallel()
+
+	var status atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(int(status.Load()))
+	}))
+	defer server.Close()
+
+	procs := Processors{processors: map[entity.Format]processor{entity.FormatRaw: NewRaw(server.Client())}}
+
+	for code, transient := range map[int]bool{
+		http.StatusBadGateway:      true,
+		http.StatusTooManyRequests: true,
+		http.StatusNotFound:        false,
+		http.StatusForbidden:       false,
+	} {
+		status.Store(int32(code))
+
+		result := procs.Process(context.Background(), entity.FormatRaw, &entity.PageBase{URL: server.URL}, entity.NewCache())
+		require.Error(t, result.Err)
+		assert.Equal(t, transient, entity.IsTransient(result.Err), code)
+	}
+
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	result := procs.Process(context.Background(), entity.FormatRaw, &entity.PageBase{URL: closed.URL}, entity.NewCache())
+	require.Error(t, result.Err)
+	assert.True(t, entity.IsTransient(result.Err))
+}
@@ -0,0 +1,57 @@
+package processors
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/derfenix/webarchive/entity"
+)
+
+// Profiles provides the credential profiles applied to the page requests, see entity.Profile.
+type Profiles interface {
+	ListAll(ctx context.Context) ([]*entity.Profile, error)
+}
+
+type profilesKey struct{}
+
+func withProfiles(ctx context.Context, profiles []*entity.Profile) context.Context {
+	return context.WithValue(ctx, profilesKey{}, profiles)
+}
+
+// profileFor returns the profile matching the host from the context profiles, or nil.
+func profileFor(ctx context.Context, host string) *entity.Profile {
+	profiles, _ := ctx.Value(profilesKey{}).([]*entity.Profile)
+
+	return entity.MatchProfile(profiles, host)
+}
+
+// applyProfile adds the profile credentials to the request, the values set by processors are kept.
+func applyProfile(req *http.Request, profile *entity.Profile) {
+	for name, value := range profile.Headers {
+		if req.Header.Get(name) == "" {
+			req.Header.Set(name, value)
+		}
+	}
+
+	if profile.Username != "" && req.Header.Get("Authorization") == "" {
+		req.SetBasicAuth(profile.Username, profile.Password)
+	}
+
+	now := time.Now()
+
+	for i := range profile.Cookies {
+		cookie := &profile.Cookies[i]
+
+		if !cookie.Matches(req.URL, now) {
+			continue
+		}
+
+		// Cookies from the jar, set by the site during the capture, are newer than the profile ones.
+		if _, err := req.Cookie(cookie.Name); err == nil {
+			continue
+		}
+
+		req.AddCookie(&http.Cookie{Name: cookie.Name, Value: cookie.Value})
+	}
+}
@@ -0,0 +1,72 @@
+package processors
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/derfenix/webarchive/config"
+	"github.com/derfenix/webarchive/entity"
+)
+
+type staticProfiles []*entity.Profile
+
+func (p staticProfiles) ListAll(context.Context) ([]*entity.Profile, error) {
+	return p, nil
+}
+
+func TestProcessors_Profiles(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		session, err := r.Cookie("session")
+
+		if !ok || username != "user" || password != "pass" || err != nil || session.Value != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		_, _ = w.Write([]byte("<html><head><title>Private page</title></head></html>"))
+	}))
+	t.Cleanup(server.Close)
+
+	profile := entity.NewProfile("local", "127.0.0.1")
+	profile.Username = "user"
+	profile.Password = "pass"
+	profile.Cookies = []entity.Cookie{{Name: "session", Value: "secret", Domain: "127.0.0.1", Path: "/"}}
+
+	client, err := NewHTTPClient(config.Client{MaxRedirects: 10}, config.Politeness{}, config.Egress{})
+	require.NoError(t, err)
+
+	procs := &Processors{
+		client: client,
+		processors: map[entity.Format]processor{
+			entity.FormatWARC: NewWARC(config.Inline{Concurrency: 1}, client, zaptest.NewLogger(t)),
+		},
+	}
+
+	_, err = procs.GetMeta(context.Background(), server.URL, entity.NewCache())
+	assert.ErrorContains(t, err, "want status 200, got 401")
+
+	procs.WithProfiles(staticProfiles{profile})
+
+	meta, err := procs.GetMeta(context.Background(), server.URL, entity.NewCache())
+	require.NoError(t, err)
+	assert.Equal(t, "Private page", meta.Title)
+
+	result := procs.Process(context.Background(), entity.FormatWARC, &entity.PageBase{URL: server.URL}, entity.NewCache())
+	require.NoError(t, result.Err)
+	require.Len(t, result.Files, 1)
+
+	warc := string(result.Files[0].Data)
+	assert.Contains(t, warc, "Private page")
+	assert.NotContains(t, warc, "Authorization", "profile credentials are not recorded")
+	assert.NotContains(t, warc, "secret")
+}
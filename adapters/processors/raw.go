@@ -0,0 +1,91 @@
+package processors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gabriel-vasile/mimetype"
+
+	"github.com/derfenix/webarchive/entity"
+)
+
+const (
+	rawBodyFilename     = "body"
+	rawResponseFilename = "response.json"
+)
+
+func NewRaw(client *http.Client) *Raw {
+	return &Raw{client: client}
+}
+
+// Raw saves the exact bytes of the page response body, without any reparsing and decompression, and the response
+// details in the separate file.
+type Raw struct {
+	client *http.Client
+}
+
+type rawResponse struct {
+	URL        string      `json:"url"`
+	FinalURL   string      `json:"final_url"`
+	Protocol   string      `json:"protocol"`
+	StatusCode int         `json:"status_code"`
+	Status     string      `json:"status"`
+	Headers    http.Header `json:"headers"`
+	Fetched    time.Time   `json:"fetched"`
+	Size       int         `json:"size"`
+}
+
+// Process requests the page again instead of using the cache, as the cached body is decompressed by the client.
+// The explicit Accept-Encoding header disables the transparent decompression, so the body is stored as sent
+// by the server, and its Content-Encoding is recorded in the response headers.
+func (r *Raw) Process(ctx context.Context, page *entity.PageBase, _ *entity.Cache) ([]entity.File, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, page.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("new request: %w", err)
+	}
+
+	req.Header.Set("Accept-Encoding", "identity")
+
+	response, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("do request: %w", err)
+	}
+
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, &statusError{code: response.StatusCode}
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response body: %w", err)
+	}
+
+	details := rawResponse{
+		URL:        page.URL,
+		FinalURL:   response.Request.URL.String(),
+		Protocol:   response.Proto,
+		StatusCode: response.StatusCode,
+		Status:     response.Status,
+		Headers:    response.Header,
+		Fetched:    time.Now().UTC(),
+		Size:       len(body),
+	}
+
+	detailsData, err := json.MarshalIndent(details, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal response details: %w", err)
+	}
+
+	return []entity.File{
+		entity.NewFile(rawBodyFilename+mimetype.Detect(body).Extension(), body),
+		entity.NewFile(rawResponseFilename, detailsData),
+	}, nil
+}
@@ -0,0 +1,85 @@
+package processors
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/derfenix/webarchive/entity"
+)
+
+func TestRaw_Process(t *testing.T) {
+	t.Parallel()
+
+	const body = "<html><HEAD><title>Raw</title></HEAD><body><p>unclosed<br></body></html>"
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/page", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/page", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(body))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	files, err := NewRaw(server.Client()).Process(context.Background(), &entity.PageBase{URL: server.URL + "/old"}, entity.NewCache())
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	assert.Equal(t, "body.html", files[0].Name)
+	assert.Equal(t, body, string(files[0].Data))
+
+	assert.Equal(t, "response.json", files[1].Name)
+
+	var details rawResponse
+	require.NoError(t, json.Unmarshal(files[1].Data, &details))
+
+	assert.Equal(t, server.URL+"/old", details.URL)
+	assert.Equal(t, server.URL+"/page", details.FinalURL)
+	assert.Equal(t, "HTTP/1.1", details.Protocol)
+	assert.Equal(t, http.StatusOK, details.StatusCode)
+	assert.Equal(t, len(body), details.Size)
+	assert.Equal(t, "text/html", details.Headers.Get("Content-Type"))
+}
+
+func TestRaw_ProcessCompressed(t *testing.T) {
+	t.Parallel()
+
+	compressed := bytes.NewBuffer(nil)
+	writer := gzip.NewWriter(compressed)
+	_, err := writer.Write([]byte("<html><body>compressed</body></html>"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "identity", r.Header.Get("Accept-Encoding"))
+
+		// The server ignores the requested encoding.
+		w.Header().Set("Content-Encoding", "gzip")
+		_, _ = w.Write(compressed.Bytes())
+	}))
+	t.Cleanup(server.Close)
+
+	files, err := NewRaw(server.Client()).Process(context.Background(), &entity.PageBase{URL: server.URL}, entity.NewCache())
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	assert.Equal(t, "body.gz", files[0].Name)
+	assert.Equal(t, compressed.Bytes(), files[0].Data)
+
+	var details rawResponse
+	require.NoError(t, json.Unmarshal(files[1].Data, &details))
+
+	assert.Equal(t, "gzip", details.Headers.Get("Content-Encoding"))
+	assert.Equal(t, compressed.Len(), details.Size)
+}
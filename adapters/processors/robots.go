@@ -0,0 +1,166 @@
+package processors
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// robotsRules are the robots.txt rules of the group matching the user agent, see RFC 9309.
+type robotsRules struct {
+	rules      []robotsRule
+	crawlDelay time.Duration
+}
+
+type robotsRule struct {
+	allow   bool
+	pattern string
+	re      *regexp.Regexp
+}
+
+type robotsGroup struct {
+	agents     []string
+	rules      []robotsRule
+	crawlDelay time.Duration
+	// closed is set by the first rule line, the next user-agent line starts the new group.
+	closed bool
+}
+
+// parseRobots returns the rules of the groups naming the userAgent product token, compared case-insensitively,
+// or of the * groups.
+func parseRobots(data []byte, userAgent string) *robotsRules {
+	var groups []*robotsGroup
+	var group *robotsGroup
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			// Consecutive user-agent lines share the group.
+			if group == nil || group.closed {
+				group = &robotsGroup{}
+				groups = append(groups, group)
+			}
+
+			group.agents = append(group.agents, strings.ToLower(value))
+
+		case "allow", "disallow":
+			if group == nil {
+				continue
+			}
+
+			group.closed = true
+
+			// Empty disallow allows everything, it is the same as no rule.
+			if value == "" {
+				continue
+			}
+
+			re, err := robotsPattern(value)
+			if err != nil {
+				continue
+			}
+
+			group.rules = append(group.rules, robotsRule{allow: key == "allow", pattern: value, re: re})
+
+		case "crawl-delay":
+			if group == nil {
+				continue
+			}
+
+			group.closed = true
+
+			if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds > 0 {
+				group.crawlDelay = time.Duration(seconds * float64(time.Second))
+			}
+		}
+	}
+
+	userAgent = strings.ToLower(userAgent)
+
+	specific := &robotsRules{}
+	common := &robotsRules{}
+	// The matching group applies even without rules.
+	specificMatched := false
+
+	for _, group := range groups {
+		for _, agent := range group.agents {
+			target := common
+
+			switch {
+			case agent == "*":
+			case agent == userAgent:
+				target = specific
+				specificMatched = true
+			default:
+				continue
+			}
+
+			target.rules = append(target.rules, group.rules...)
+			target.crawlDelay = max(target.crawlDelay, group.crawlDelay)
+
+			break
+		}
+	}
+
+	if specificMatched {
+		return specific
+	}
+
+	return common
+}
+
+// robotsPattern converts the rule path pattern with * wildcards and $ end anchor to regexp.
+func robotsPattern(pattern string) (*regexp.Regexp, error) {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+
+	parts := strings.Split(pattern, "*")
+	for i := range parts {
+		parts[i] = regexp.QuoteMeta(parts[i])
+	}
+
+	expr := "^" + strings.Join(parts, ".*")
+	if anchored {
+		expr += "$"
+	}
+
+	return regexp.Compile(expr)
+}
+
+// allowed reports whether the path with query is allowed. The longest matching rule wins, allow rule wins
+// the rules of the same length.
+func (r *robotsRules) allowed(path string) bool {
+	if path == "/robots.txt" {
+		return true
+	}
+
+	allowed := true
+	matchedLength := -1
+
+	for _, rule := range r.rules {
+		if !rule.re.MatchString(path) {
+			continue
+		}
+
+		if length := len(rule.pattern); length > matchedLength || length == matchedLength && rule.allow {
+			allowed = rule.allow
+			matchedLength = length
+		}
+	}
+
+	return allowed
+}
@@ -0,0 +1,171 @@
+package processors
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"strconv"
+	"strings"
+
+	"github.com/disintegration/imaging"
+
+	"github.com/derfenix/webarchive/config"
+	"github.com/derfenix/webarchive/entity"
+)
+
+const wkhtmltoimageBinary = "wkhtmltoimage"
+
+func NewScreenshot(cfg config.Screenshot, clientCfg config.Client, egress *EgressProxy) (*Screenshot, error) {
+	width, height, err := parseViewport(cfg.Viewport)
+	if err != nil {
+		return nil, fmt.Errorf("parse viewport: %w", err)
+	}
+
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("invalid viewport %q, positive size expected", cfg.Viewport)
+	}
+
+	if cfg.ThumbnailWidth <= 0 {
+		return nil, fmt.Errorf("invalid thumbnail width %d, positive value expected", cfg.ThumbnailWidth)
+	}
+
+	if cfg.MaxHeight < 0 {
+		return nil, fmt.Errorf("invalid max height %d", cfg.MaxHeight)
+	}
+
+	if cfg.Quality < 0 || cfg.Quality > 100 {
+		return nil, fmt.Errorf("invalid quality %d, 0-100 expected", cfg.Quality)
+	}
+
+	return &Screenshot{
+		cfg:       cfg,
+		clientCfg: clientCfg,
+		egress:    egress,
+		binary:    wkhtmltoimageBinary,
+		width:     width,
+		height:    height,
+	}, nil
+}
+
+// Screenshot renders full-page PNG image of the page and its downscaled thumbnail.
+type Screenshot struct {
+	cfg       config.Screenshot
+	clientCfg config.Client
+	egress    *EgressProxy
+	binary    string
+	width     int
+	height    int
+}
+
+// Process renders the cached page document, so the screenshot shows the same page version as other formats.
+// The page is loaded by its URL if the document is not cached.
+func (s *Screenshot) Process(ctx context.Context, page *entity.PageBase, cache *entity.Cache) ([]entity.File, error) {
+	clientOpts, err := newWkhtmlOptions(ctx, s.clientCfg, s.egress, page.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{
+		"--quiet",
+		"--format", "png",
+		"--width", strconv.Itoa(s.width),
+		"--quality", strconv.Itoa(s.cfg.Quality),
+		"--javascript-delay", "200",
+		"--load-error-handling", "ignore",
+		"--load-media-error-handling", "ignore",
+	}
+	args = append(args, clientOpts.args()...)
+
+	if len(clientOpts.cookies) > 0 {
+		cookieJar, removeCookieJar, err := writeCookieJar(clientOpts.cookies)
+		if err != nil {
+			return nil, fmt.Errorf("write cookie jar: %w", err)
+		}
+
+		defer removeCookieJar()
+
+		args = append(args, "--cookie-jar", cookieJar)
+	}
+
+	input := page.URL
+
+	if document := cache.Get(); len(document) > 0 {
+		documentFile, removeDocument, err := writeDocumentFile(document, page.URL, page.Meta.Encoding)
+		if err != nil {
+			return nil, fmt.Errorf("write document: %w", err)
+		}
+
+		defer removeDocument()
+
+		input = documentFile
+		args = append(args, "--allow", documentFile)
+	}
+
+	screenshotData, err := runWkhtml(ctx, s.binary, args, append(clientOpts.headerArgs(), input, "-"))
+	if err != nil {
+		return nil, err
+	}
+
+	screenshot, err := imaging.Decode(bytes.NewReader(screenshotData))
+	if err != nil {
+		return nil, fmt.Errorf("decode screenshot: %w", err)
+	}
+
+	if size := screenshot.Bounds().Size(); s.cfg.MaxHeight > 0 && size.Y > s.cfg.MaxHeight {
+		screenshot = imaging.CropAnchor(screenshot, size.X, s.cfg.MaxHeight, imaging.Top)
+
+		screenshotData, err = encodeImage(screenshot, imaging.PNG)
+		if err != nil {
+			return nil, fmt.Errorf("encode cropped screenshot: %w", err)
+		}
+	}
+
+	// Thumbnail shows the first screen of the page only.
+	thumbnail := screenshot
+	if size := thumbnail.Bounds().Size(); size.Y > s.height {
+		thumbnail = imaging.CropAnchor(thumbnail, size.X, s.height, imaging.Top)
+	}
+
+	thumbnail = imaging.Resize(thumbnail, s.cfg.ThumbnailWidth, 0, imaging.Lanczos)
+
+	thumbnailData, err := encodeImage(thumbnail, imaging.JPEG, imaging.JPEGQuality(s.cfg.Quality))
+	if err != nil {
+		return nil, fmt.Errorf("encode thumbnail: %w", err)
+	}
+
+	return []entity.File{
+		entity.NewFile(s.cfg.Filename, screenshotData),
+		entity.NewFile(entity.ThumbnailFilename, thumbnailData),
+	}, nil
+}
+
+func encodeImage(img image.Image, format imaging.Format, opts ...imaging.EncodeOption) ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+
+	if err := imaging.Encode(buf, img, format, opts...); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// parseViewport parses viewport value in format used by wkhtmltopdf, e.g. 1280x720.
+func parseViewport(viewport string) (int, int, error) {
+	rawWidth, rawHeight, found := strings.Cut(viewport, "x")
+	if !found {
+		return 0, 0, fmt.Errorf("invalid viewport %q, want WIDTHxHEIGHT", viewport)
+	}
+
+	width, err := strconv.Atoi(rawWidth)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid viewport width: %w", err)
+	}
+
+	height, err := strconv.Atoi(rawHeight)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid viewport height: %w", err)
+	}
+
+	return width, height, nil
+}
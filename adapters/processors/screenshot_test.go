@@ -0,0 +1,136 @@
+package processors
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/charmap"
+
+	"github.com/derfenix/webarchive/config"
+	"github.com/derfenix/webarchive/entity"
+)
+
+func TestParseViewport(t *testing.T) {
+	t.Parallel()
+
+	width, height, err := parseViewport("1280x720")
+	require.NoError(t, err)
+	assert.Equal(t, 1280, width)
+	assert.Equal(t, 720, height)
+
+	_, _, err = parseViewport("1280")
+	assert.Error(t, err)
+
+	_, _, err = parseViewport("widex720")
+	assert.Error(t, err)
+}
+
+func TestNewScreenshot(t *testing.T) {
+	t.Parallel()
+
+	valid := config.Screenshot{Viewport: "1280x720", MaxHeight: 20000, Quality: 90, ThumbnailWidth: 400}
+
+	_, err := NewScreenshot(valid, config.Client{}, nil)
+	require.NoError(t, err)
+
+	for name, modify := range map[string]func(cfg *config.Screenshot){
+		"viewport":        func(cfg *config.Screenshot) { cfg.Viewport = "1280" },
+		"zero viewport":   func(cfg *config.Screenshot) { cfg.Viewport = "0x720" },
+		"thumbnail width": func(cfg *config.Screenshot) { cfg.ThumbnailWidth = 0 },
+		"max height":      func(cfg *config.Screenshot) { cfg.MaxHeight = -1 },
+		"quality":         func(cfg *config.Screenshot) { cfg.Quality = 101 },
+	} {
+		cfg := valid
+		modify(&cfg)
+
+		_, err := NewScreenshot(cfg, config.Client{}, nil)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestScreenshot_Process(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	rendered := imaging.New(1280, 3000, color.White)
+	renderedData, err := encodeImage(rendered, imaging.PNG)
+	require.NoError(t, err)
+
+	renderedPath := filepath.Join(dir, "rendered.png")
+	require.NoError(t, os.WriteFile(renderedPath, renderedData, 0o600))
+
+	// The fake wkhtmltoimage saves its arguments and the rendered document, and prints the prepared image.
+	script := `#!/bin/sh
+printf '%s\n' "$@" > "` + dir + `/args"
+cat > "` + dir + `/stdin"
+for arg in "$@"; do
+	if [ "$prev" = "--allow" ]; then cp "$arg" "` + dir + `/document.html"; fi
+	prev="$arg"
+done
+cat "` + renderedPath + `"
+`
+	binary := filepath.Join(dir, "wkhtmltoimage")
+	require.NoError(t, os.WriteFile(binary, []byte(script), 0o700)) //nolint:gosec // test script must be executable
+
+	screenshot, err := NewScreenshot(
+		config.Screenshot{Viewport: "1280x720", MaxHeight: 2000, Quality: 90, ThumbnailWidth: 400, Filename: "screenshot.png"},
+		config.Client{},
+		nil,
+	)
+	require.NoError(t, err)
+
+	screenshot.binary = binary
+
+	// The encoding is declared by the Content-Type header only.
+	body, err := charmap.Windows1251.NewEncoder().String("кэш")
+	require.NoError(t, err)
+
+	cache := entity.NewCache()
+	_, err = cache.Write([]byte(`<html><head><title>Cached</title></head><body>` + body + `</body></html>`))
+	require.NoError(t, err)
+
+	page := &entity.PageBase{URL: "https://example.com/page", Meta: entity.Meta{Encoding: "windows-1251"}}
+
+	files, err := screenshot.Process(context.Background(), page, cache)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	assert.Equal(t, "screenshot.png", files[0].Name)
+	assert.Equal(t, image.Pt(1280, 2000), decodeImageSize(t, files[0].Data))
+
+	assert.Equal(t, entity.ThumbnailFilename, files[1].Name)
+	assert.Equal(t, image.Pt(400, 225), decodeImageSize(t, files[1].Data))
+
+	// The cached document is rendered in its encoding, its resources are loaded from the page host.
+	document, err := os.ReadFile(filepath.Join(dir, "document.html"))
+	require.NoError(t, err)
+	assert.Equal(t, `<html><head><meta charset="windows-1251"><base href="https://example.com/page"><title>Cached</title></head><body>`+
+		body+`</body></html>`, string(document))
+
+	stdin, err := os.ReadFile(filepath.Join(dir, "stdin"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(stdin), "https://example.com/page")
+
+	args, err := os.ReadFile(filepath.Join(dir, "args"))
+	require.NoError(t, err)
+	assert.Contains(t, string(args), "--read-args-from-stdin\n")
+	assert.Contains(t, string(args), "--width\n1280\n")
+}
+
+func decodeImageSize(t *testing.T, data []byte) image.Point {
+	t.Helper()
+
+	img, err := imaging.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	return img.Bounds().Size()
+}
@@ -5,40 +5,66 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"slices"
 
 	"go.uber.org/zap"
 	"golang.org/x/net/html"
 
 	"github.com/derfenix/webarchive/adapters/processors/internal"
+	"github.com/derfenix/webarchive/config"
 	"github.com/derfenix/webarchive/entity"
 )
 
-func NewSingleFile(client *http.Client, log *zap.Logger) *SingleFile {
-	return &SingleFile{client: client, log: log}
+func NewSingleFile(cfg config.SingleFile, inlineCfg config.Inline, client *http.Client, log *zap.Logger) (*SingleFile, error) {
+	hosts := cfg.BlockedHosts
+	selectors := cfg.BlockedSelectors
+
+	if cfg.DefaultBlocklist {
+		hosts = append(slices.Clone(internal.DefaultBlockedHosts), hosts...)
+		selectors = append(slices.Clone(internal.DefaultBlockedSelectors), selectors...)
+	}
+
+	cleaner, err := internal.NewStaticCleaner(hosts, selectors)
+	if err != nil {
+		return nil, fmt.Errorf("new static cleaner: %w", err)
+	}
+
+	return &SingleFile{cfg: cfg, inlineCfg: inlineCfg, cleaner: cleaner, client: client, log: log}, nil
 }
 
 type SingleFile struct {
-	client *http.Client
-	log    *zap.Logger
+	cfg       config.SingleFile
+	inlineCfg config.Inline
+	cleaner   *internal.StaticCleaner
+	client    *http.Client
+	log       *zap.Logger
 }
 
-func (s *SingleFile) Process(ctx context.Context, pageURL string, cache *entity.Cache) ([]entity.File, error) {
-	reader := cache.Reader()
+func (s *SingleFile) Process(ctx context.Context, page *entity.PageBase, cache *entity.Cache) ([]entity.File, error) {
+	reader, err := pageDocument(ctx, s.client, page, cache)
+	if err != nil {
+		return nil, err
+	}
+
+	getter := func(ctx context.Context, url string) (*http.Response, error) {
+		return cachedGet(ctx, s.client, cache, url)
+	}
 
-	if reader == nil {
-		response, err := s.get(ctx, pageURL)
-		if err != nil {
-			return nil, err
-		}
+	inline := internal.NewMediaInline(s.log, getter).
+		WithConcurrency(s.inlineCfg.Concurrency).
+		WithFrameDepth(s.inlineCfg.FrameDepth).
+		WithImages(s.inlineCfg.Images)
 
-		defer func() {
-			_ = response.Body.Close()
-		}()
+	static := s.cfg.Static
+	if page.Options.SingleFile.Static != nil {
+		static = *page.Options.SingleFile.Static
+	}
 
-		reader = response.Body
+	if static {
+		inline = inline.WithStatic(s.cleaner)
 	}
 
-	inlinedHTML, err := internal.NewMediaInline(s.log, s.get).Inline(ctx, reader, pageURL)
+	inlinedHTML, err := inline.Inline(ctx, reader, page.URL)
 	if err != nil {
 		return nil, fmt.Errorf("inline media: %w", err)
 	}
@@ -52,25 +78,3 @@ func (s *SingleFile) Process(ctx context.Context, pageURL string, cache *entity.
 
 	return []entity.File{htmlFile}, nil
 }
-
-func (s *SingleFile) get(ctx context.Context, url string) (*http.Response, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("new request: %w", err)
-	}
-
-	response, err := s.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("do request: %w", err)
-	}
-
-	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("want status 200, got %d", response.StatusCode)
-	}
-
-	if response.Body == nil {
-		return nil, fmt.Errorf("empty response body")
-	}
-
-	return response, nil
-}
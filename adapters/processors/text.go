@@ -0,0 +1,221 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+
+	"github.com/derfenix/webarchive/entity"
+)
+
+func NewText(client *http.Client) *Text {
+	return &Text{client: client}
+}
+
+// Text saves normalized plain text of the page, with link targets listed as footnotes.
+type Text struct {
+	client *http.Client
+}
+
+func (t *Text) Process(ctx context.Context, page *entity.PageBase, cache *entity.Cache) ([]entity.File, error) {
+	reader, err := pageDocument(ctx, t.client, page, cache)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := html.Parse(reader)
+	if err != nil {
+		return nil, fmt.Errorf("parse html: %w", err)
+	}
+
+	baseURL, err := url.Parse(page.URL)
+	if err != nil {
+		return nil, fmt.Errorf("parse page url: %w", err)
+	}
+
+	writer := newTextWriter(baseURL)
+	writer.walk(document)
+
+	return []entity.File{entity.NewFile("page.txt", []byte(writer.String()))}, nil
+}
+
+var (
+	textSkipElements = map[string]struct{}{
+		"head": {}, "script": {}, "style": {}, "noscript": {}, "template": {},
+		"svg": {}, "canvas": {}, "iframe": {}, "object": {}, "embed": {},
+	}
+	textParagraphElements = map[string]struct{}{
+		"p": {}, "h1": {}, "h2": {}, "h3": {}, "h4": {}, "h5": {}, "h6": {},
+		"blockquote": {}, "pre": {}, "ul": {}, "ol": {}, "dl": {}, "table": {},
+		"article": {}, "section": {}, "header": {}, "footer": {}, "nav": {},
+		"aside": {}, "main": {}, "figure": {}, "form": {}, "address": {}, "hr": {},
+	}
+	textLineElements = map[string]struct{}{
+		"br": {}, "div": {}, "li": {}, "tr": {}, "dt": {}, "dd": {}, "figcaption": {}, "caption": {},
+	}
+)
+
+type textWriter struct {
+	baseURL      *url.URL
+	builder      strings.Builder
+	links        []string
+	linkIndexes  map[string]int
+	pendingBreak int
+	pendingSpace bool
+	preformatted int
+}
+
+func newTextWriter(baseURL *url.URL) *textWriter {
+	return &textWriter{baseURL: baseURL, linkIndexes: make(map[string]int)}
+}
+
+func (w *textWriter) walk(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		w.text(n.Data)
+
+		return
+
+	case html.ElementNode:
+		if _, skip := textSkipElements[n.Data]; skip {
+			return
+		}
+	}
+
+	breaks := 0
+	if _, ok := textParagraphElements[n.Data]; ok && n.Type == html.ElementNode {
+		breaks = 2
+	} else if _, ok := textLineElements[n.Data]; ok && n.Type == html.ElementNode {
+		breaks = 1
+	}
+
+	w.lineBreak(breaks)
+
+	if n.Type == html.ElementNode && n.Data == "pre" {
+		w.preformatted++
+		defer func() { w.preformatted-- }()
+	}
+
+	start := w.builder.Len()
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		w.walk(c)
+	}
+
+	if n.Type == html.ElementNode && n.Data == "a" {
+		w.link(n, w.builder.Len() > start)
+	}
+
+	w.lineBreak(breaks)
+}
+
+func (w *textWriter) text(data string) {
+	if w.preformatted > 0 {
+		w.write(data)
+
+		return
+	}
+
+	fields := strings.Fields(data)
+	if len(fields) == 0 {
+		if data != "" {
+			w.pendingSpace = true
+		}
+
+		return
+	}
+
+	if strings.TrimLeft(data, " \t\r\n\f") != data {
+		w.pendingSpace = true
+	}
+
+	w.write(strings.Join(fields, " "))
+
+	if strings.TrimRight(data, " \t\r\n\f") != data {
+		w.pendingSpace = true
+	}
+}
+
+func (w *textWriter) write(s string) {
+	if w.builder.Len() > 0 {
+		switch {
+		case w.pendingBreak > 0:
+			w.builder.WriteString(strings.Repeat("\n", w.pendingBreak))
+		case w.pendingSpace:
+			w.builder.WriteString(" ")
+		}
+	}
+
+	w.pendingBreak = 0
+	w.pendingSpace = false
+
+	w.builder.WriteString(s)
+}
+
+func (w *textWriter) lineBreak(breaks int) {
+	if breaks > w.pendingBreak {
+		w.pendingBreak = breaks
+	}
+}
+
+func (w *textWriter) link(n *html.Node, hasText bool) {
+	var href string
+
+	for _, attr := range n.Attr {
+		if attr.Key == "href" {
+			href = strings.TrimSpace(attr.Val)
+		}
+	}
+
+	if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(strings.ToLower(href), "javascript:") {
+		return
+	}
+
+	if parsedHref, err := url.Parse(href); err == nil {
+		href = w.baseURL.ResolveReference(parsedHref).String()
+	}
+
+	idx, ok := w.linkIndexes[href]
+	if !ok {
+		w.links = append(w.links, href)
+		idx = len(w.links)
+		w.linkIndexes[href] = idx
+	}
+
+	mark := fmt.Sprintf("[%d]", idx)
+
+	// Footnote mark sticks to the link text, pending whitespace and breaks are kept for the text after the mark.
+	// The mark of the link without text, like the image link, is separated as the text.
+	if !hasText {
+		w.write(mark)
+
+		return
+	}
+
+	pendingBreak, pendingSpace := w.pendingBreak, w.pendingSpace
+	w.pendingBreak, w.pendingSpace = 0, false
+
+	w.write(mark)
+
+	w.pendingBreak, w.pendingSpace = pendingBreak, pendingSpace
+}
+
+func (w *textWriter) String() string {
+	result := strings.Builder{}
+	result.WriteString(strings.TrimSpace(w.builder.String()))
+	result.WriteString("\n")
+
+	if len(w.links) > 0 {
+		result.WriteString("\n")
+
+		for i, link := range w.links {
+			_, _ = fmt.Fprintf(&result, "[%d] %s\n", i+1, link)
+		}
+	}
+
+	return result.String()
+}
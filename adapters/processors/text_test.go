@@ -0,0 +1,58 @@
+package processors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/derfenix/webarchive/entity"
+)
+
+func TestText_Process(t *testing.T) {
+	t.Parallel()
+
+	cache := entity.NewCache()
+	_, err := cache.Write([]byte(`<html><head><title>Title</title><style>p { color: red; }</style></head>
+<body>
+<script>alert("hello")</script>
+<h1>Header</h1>
+<p>First    paragraph
+with <a href="/first">a link</a> and <a href="https://example.org/">another one</a>.</p>
+<p>Second paragraph<br>with line break and <a href="/first">the same link</a>.</p>
+<ul><li>One</li><li>Two</li></ul>
+<a href="/image"><img src="/image.png"></a>
+<a href="/wrapped"><p>Wrapped paragraph</p></a>
+<p>Last <a href="/last">link </a>text</p>
+</body></html>`))
+	require.NoError(t, err)
+
+	files, err := NewText(nil).Process(context.Background(), &entity.PageBase{URL: "https://example.com/page"}, cache)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	assert.Equal(t, "page.txt", files[0].Name)
+	assert.Equal(t, `Header
+
+First paragraph with a link[1] and another one[2].
+
+Second paragraph
+with line break and the same link[1].
+
+One
+Two
+
+[3]
+
+Wrapped paragraph[4]
+
+Last link[5] text
+
+[1] https://example.com/first
+[2] https://example.org/
+[3] https://example.com/image
+[4] https://example.com/wrapped
+[5] https://example.com/last
+`, string(files[0].Data))
+}
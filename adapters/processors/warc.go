@@ -0,0 +1,210 @@
+package processors
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/derfenix/webarchive/adapters/processors/internal"
+	"github.com/derfenix/webarchive/config"
+	"github.com/derfenix/webarchive/entity"
+)
+
+const (
+	warcFilename     = "page.warc"
+	warcMaxRedirects = 3
+)
+
+func NewWARC(cfg config.Inline, client *http.Client, log *zap.Logger) *WARC {
+	// Redirects are followed manually, so every hop is recorded.
+	noRedirectClient := *client
+	noRedirectClient.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	return &WARC{cfg: cfg, client: &noRedirectClient, log: log}
+}
+
+type WARC struct {
+	cfg    config.Inline
+	client *http.Client
+	log    *zap.Logger
+}
+
+func (w *WARC) Process(ctx context.Context, page *entity.PageBase, cache *entity.Cache) ([]entity.File, error) {
+	if cache == nil {
+		cache = entity.NewCache()
+	}
+
+	buf := bytes.NewBuffer(nil)
+
+	writer := internal.NewWARCWriter(buf)
+	if err := writer.WriteInfo(warcFilename); err != nil {
+		return nil, fmt.Errorf("write warc info: %w", err)
+	}
+
+	document, cached := cache.Document()
+
+	response, body, err := w.get(ctx, writer, page.URL, document, cached)
+	if err != nil {
+		return nil, fmt.Errorf("get page: %w", err)
+	}
+
+	// Other content has no resources, the response record is enough.
+	if !page.Meta.IsHTML() {
+		return []entity.File{entity.NewFile(warcFilename, buf.Bytes())}, nil
+	}
+
+	if !cached {
+		cache.SetDocument(cachedResource(response, body))
+	}
+
+	getter := func(ctx context.Context, url string) (*http.Response, error) {
+		resource, cached := cache.Resource(url)
+
+		response, body, err := w.get(ctx, writer, url, resource, cached)
+		if err != nil {
+			return nil, err
+		}
+
+		if !cached {
+			cache.SetResource(url, cachedResource(response, body))
+		}
+
+		return response, nil
+	}
+
+	// Inlined document is not needed, inlining is used to fetch and record all page resources.
+	inline := internal.NewMediaInline(w.log, getter).
+		WithConcurrency(w.cfg.Concurrency).
+		WithFrameDepth(w.cfg.FrameDepth)
+
+	reader, err := decodeDocument(page, bytes.NewReader(body), response.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := inline.Inline(ctx, reader, response.Request.URL.String()); err != nil {
+		return nil, fmt.Errorf("inline media: %w", err)
+	}
+
+	file := entity.NewFile(warcFilename, buf.Bytes())
+
+	return []entity.File{file}, nil
+}
+
+// get records the exchanges of url to the writer and returns the final response with its body.
+// The cached response is recorded as is, only the redirect hops to it are requested, as the cache
+// keeps the final response only. Not cached url is requested following redirects.
+func (w *WARC) get(
+	ctx context.Context,
+	writer *internal.WARCWriter,
+	url string,
+	resource entity.CachedResource,
+	cached bool,
+) (*http.Response, []byte, error) {
+	for hop := 0; hop <= warcMaxRedirects; hop++ {
+		if cached && (resource.FinalURL == "" || resource.FinalURL == url) {
+			return w.writeCached(ctx, writer, url, resource)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("new request: %w", err)
+		}
+
+		date := time.Now()
+
+		response, err := w.client.Do(req)
+		if err != nil {
+			return nil, nil, fmt.Errorf("do request: %w", err)
+		}
+
+		var body []byte
+		if response.Body != nil {
+			body, err = io.ReadAll(response.Body)
+			_ = response.Body.Close()
+
+			if err != nil {
+				return nil, nil, fmt.Errorf("read response body: %w", err)
+			}
+		}
+
+		redirect := response.StatusCode >= http.StatusMultipleChoices && response.StatusCode < http.StatusBadRequest
+
+		// The url does not redirect to the cached response anymore, the cached one is recorded still,
+		// so the archive matches the other formats.
+		if cached && !redirect {
+			return w.writeCached(ctx, writer, resource.FinalURL, resource)
+		}
+
+		if err := writer.WriteExchange(response, body, date); err != nil {
+			return nil, nil, fmt.Errorf("write exchange: %w", err)
+		}
+
+		response.Body = io.NopCloser(bytes.NewReader(body))
+
+		if redirect {
+			location, err := response.Location()
+			if err != nil {
+				return nil, nil, fmt.Errorf("get redirect location: %w", err)
+			}
+
+			url = location.String()
+
+			continue
+		}
+
+		if response.StatusCode != http.StatusOK {
+			return nil, nil, &statusError{code: response.StatusCode}
+		}
+
+		return response, body, nil
+	}
+
+	return nil, nil, fmt.Errorf("too many redirects")
+}
+
+// writeCached records the cached resource as the response to url. The response date is taken from
+// the cached Date header, if any.
+func (w *WARC) writeCached(
+	ctx context.Context,
+	writer *internal.WARCWriter,
+	url string,
+	resource entity.CachedResource,
+) (*http.Response, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("new request: %w", err)
+	}
+
+	header := cachedHeader(resource)
+
+	date, err := http.ParseTime(header.Get("Date"))
+	if err != nil {
+		date = time.Now()
+	}
+
+	response := &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(resource.Data)),
+		ContentLength: int64(len(resource.Data)),
+		Request:       req,
+	}
+
+	if err := writer.WriteExchange(response, resource.Data, date); err != nil {
+		return nil, nil, fmt.Errorf("write exchange: %w", err)
+	}
+
+	return response, resource.Data, nil
+}
@@ -0,0 +1,117 @@
+package processors
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/derfenix/webarchive/config"
+	"github.com/derfenix/webarchive/entity"
+)
+
+func TestWARC_Process(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/page", http.StatusFound)
+	})
+	mux.HandleFunc("/page", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(`<html><head><title>Test</title></head><body><img src="/image.png"></body></html>`))
+	})
+	mux.HandleFunc("/image.png", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write([]byte("not really a png"))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	files, err := NewWARC(config.Inline{Concurrency: 4, FrameDepth: 2}, server.Client(), zaptest.NewLogger(t)).Process(context.Background(), &entity.PageBase{URL: server.URL + "/"}, nil)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	file := files[0]
+	assert.Equal(t, "page.warc", file.Name)
+	assert.Equal(t, "application/warc", file.MimeType)
+
+	data := string(file.Data)
+	assert.True(t, strings.HasPrefix(data, "WARC/1.1\r\nWARC-Type: warcinfo\r\n"))
+	assert.Equal(t, 3, strings.Count(data, "WARC-Type: response\r\n"))
+	assert.Equal(t, 3, strings.Count(data, "WARC-Type: request\r\n"))
+	assert.Contains(t, data, "WARC-Target-URI: "+server.URL+"/\r\n")
+	assert.Contains(t, data, "WARC-Target-URI: "+server.URL+"/page\r\n")
+	assert.Contains(t, data, "WARC-Target-URI: "+server.URL+"/image.png\r\n")
+	assert.Contains(t, data, "HTTP/1.1 302 Found\r\n")
+	assert.Contains(t, data, "not really a png")
+}
+
+func TestWARC_ProcessCached(t *testing.T) {
+	t.Parallel()
+
+	var pageRequests, imageRequests, styleRequests atomic.Int32
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/page", http.StatusFound)
+	})
+	mux.HandleFunc("/page", func(w http.ResponseWriter, _ *http.Request) {
+		pageRequests.Add(1)
+		_, _ = w.Write([]byte(`<html><body>changed</body></html>`))
+	})
+	mux.HandleFunc("/image.png", func(w http.ResponseWriter, _ *http.Request) {
+		imageRequests.Add(1)
+		_, _ = w.Write([]byte("changed"))
+	})
+	mux.HandleFunc("/style.css", func(w http.ResponseWriter, _ *http.Request) {
+		styleRequests.Add(1)
+		w.Header().Set("Content-Type", "text/css")
+		_, _ = w.Write([]byte("body {}"))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	cache := entity.NewCache()
+	cache.SetDocument(entity.CachedResource{
+		FinalURL: server.URL + "/page",
+		Header:   http.Header{"Content-Type": {"text/html; charset=utf-8"}, "X-Cached": {"document"}},
+		Data:     []byte(`<html><head><link rel="stylesheet" href="/style.css"></head><body><img src="/image.png"></body></html>`),
+	})
+	cache.SetResource(server.URL+"/image.png", entity.CachedResource{
+		MimeType: "image/png",
+		FinalURL: server.URL + "/image.png",
+		Data:     []byte("cached png"),
+	})
+
+	page := &entity.PageBase{URL: server.URL + "/", Meta: entity.Meta{ContentType: "text/html"}}
+
+	files, err := NewWARC(config.Inline{Concurrency: 4, FrameDepth: 2}, server.Client(), zaptest.NewLogger(t)).Process(context.Background(), page, cache)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	data := string(files[0].Data)
+	assert.Equal(t, 4, strings.Count(data, "WARC-Type: response\r\n"))
+	assert.Contains(t, data, "HTTP/1.1 302 Found\r\n")
+	assert.Contains(t, data, "X-Cached: document\r\n")
+	assert.Contains(t, data, "cached png")
+	assert.Contains(t, data, "body {}")
+	assert.NotContains(t, data, "changed")
+
+	assert.Zero(t, pageRequests.Load())
+	assert.Zero(t, imageRequests.Load())
+	assert.Equal(t, int32(1), styleRequests.Load())
+
+	style, ok := cache.Resource(server.URL + "/style.css")
+	require.True(t, ok)
+	assert.Equal(t, "body {}", string(style.Data))
+	assert.Equal(t, "text/css", style.MimeType)
+}
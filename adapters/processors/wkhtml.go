@@ -0,0 +1,120 @@
+package processors
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"os"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// runWkhtml runs wkhtmltopdf or wkhtmltoimage and returns its output. The stdinArgs are appended to the args
+// by --read-args-from-stdin, so the secrets are not visible in the process list.
+func runWkhtml(ctx context.Context, binary string, args []string, stdinArgs []string) ([]byte, error) {
+	line, err := wkhtmlArgsLine(stdinArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	stdout := bytes.NewBuffer(nil)
+	stderr := bytes.NewBuffer(nil)
+
+	//nolint:gosec // arguments are not passed to shell
+	cmd := exec.CommandContext(ctx, binary, append([]string{"--read-args-from-stdin"}, args...)...)
+	cmd.Stdin = strings.NewReader(line)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("run %s: %w: %s", binary, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// wkhtmlArgsLine quotes the arguments into the single line read by --read-args-from-stdin.
+func wkhtmlArgsLine(args []string) (string, error) {
+	quoted := make([]string, len(args))
+
+	for i, arg := range args {
+		if strings.ContainsAny(arg, "\r\n") {
+			return "", fmt.Errorf("argument %q contains line break", arg)
+		}
+
+		quoted[i] = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(arg) + `"`
+	}
+
+	return strings.Join(quoted, " ") + "\n", nil
+}
+
+// writeCookieJar writes the cookies to the temporary wkhtmltopdf cookie jar, which is one Set-Cookie value per line.
+// The returned function removes the file.
+func writeCookieJar(cookies []*http.Cookie) (string, func(), error) {
+	lines := make([]string, len(cookies))
+	for i, cookie := range cookies {
+		lines[i] = cookie.String()
+	}
+
+	return writeTempFile("cookies-*.txt", []byte(strings.Join(lines, "\n")+"\n"))
+}
+
+// headTagRe matches the head start tag, the base element is inserted after it.
+var headTagRe = regexp.MustCompile(`(?i)<head(\s[^>]*)?>`)
+
+// writeDocumentFile writes the cached page document to the temporary file rendered instead of the page URL.
+// The base element is added, so the relative resources of the document are loaded from the page host. The document
+// bytes are kept as is with the declaration of the detected encoding, which may come from the Content-Type header
+// missing in the file.
+func writeDocumentFile(document []byte, pageURL string, encoding string) (string, func(), error) {
+	var head []byte
+
+	if encoding != "" {
+		head = append(head, `<meta charset="`+html.EscapeString(encoding)+`">`...)
+	}
+
+	if !bytes.Contains(bytes.ToLower(document), []byte("<base")) {
+		head = append(head, `<base href="`+html.EscapeString(pageURL)+`">`...)
+	}
+
+	if len(head) > 0 {
+		if location := headTagRe.FindIndex(document); location != nil {
+			document = slices.Concat(document[:location[1]], head, document[location[1]:])
+		} else {
+			document = slices.Concat(head, document)
+		}
+	}
+
+	return writeTempFile("page-*.html", document)
+}
+
+// writeTempFile writes the data to the file readable by the service user only.
+func writeTempFile(pattern string, data []byte) (string, func(), error) {
+	file, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", nil, fmt.Errorf("create temp file: %w", err)
+	}
+
+	remove := func() {
+		_ = os.Remove(file.Name())
+	}
+
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		remove()
+
+		return "", nil, fmt.Errorf("write temp file: %w", err)
+	}
+
+	if err := file.Close(); err != nil {
+		remove()
+
+		return "", nil, fmt.Errorf("close temp file: %w", err)
+	}
+
+	return file.Name(), remove, nil
+}
@@ -2,6 +2,7 @@ package badger
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 
@@ -15,14 +16,16 @@ import (
 
 func NewPage(db *badger.DB) (*Page, error) {
 	return &Page{
-		db:     db,
-		prefix: []byte("page:"),
+		db:          db,
+		prefix:      []byte("page:"),
+		cachePrefix: []byte("cache:"),
 	}, nil
 }
 
 type Page struct {
-	db     *badger.DB
-	prefix []byte
+	db          *badger.DB
+	prefix      []byte
+	cachePrefix []byte
 }
 
 func (p *Page) GetFile(_ context.Context, pageID, fileID uuid.UUID) (*entity.File, error) {
@@ -78,11 +81,31 @@ func (p *Page) Save(_ context.Context, page *entity.Page) error {
 		return fmt.Errorf("marshal data: %w", err)
 	}
 
+	var marshaledCache []byte
+
+	// Cache is kept until the page processing is finished, it is not needed after that.
+	if cache := page.Cache(); cache != nil && !page.Finished() {
+		marshaledCache, err = marshal(cache.Data())
+		if err != nil {
+			return fmt.Errorf("marshal cache: %w", err)
+		}
+	}
+
 	if err := p.db.Update(func(txn *badger.Txn) error {
 		if err := txn.Set(p.key(page), marshaled); err != nil {
 			return fmt.Errorf("put data: %w", err)
 		}
 
+		if marshaledCache != nil {
+			if err := txn.Set(p.cacheKey(page.ID), marshaledCache); err != nil {
+				return fmt.Errorf("put cache: %w", err)
+			}
+		} else if page.Finished() {
+			if err := txn.Delete(p.cacheKey(page.ID)); err != nil {
+				return fmt.Errorf("delete cache: %w", err)
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return fmt.Errorf("update db: %w", err)
@@ -91,6 +114,51 @@ func (p *Page) Save(_ context.Context, page *entity.Page) error {
 	return nil
 }
 
+func (p *Page) SaveCache(_ context.Context, pageID uuid.UUID, cache *entity.Cache) error {
+	if p.db.IsClosed() {
+		return repository.ErrDBClosed
+	}
+
+	marshaled, err := marshal(cache.Data())
+	if err != nil {
+		return fmt.Errorf("marshal cache: %w", err)
+	}
+
+	if err := p.db.Update(func(txn *badger.Txn) error {
+		if err := txn.Set(p.cacheKey(pageID), marshaled); err != nil {
+			return fmt.Errorf("put cache: %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("update db: %w", err)
+	}
+
+	return nil
+}
+
+// getCache returns the saved page cache, or new empty cache if there is no saved one.
+func (p *Page) getCache(txn *badger.Txn, pageID uuid.UUID) (*entity.Cache, error) {
+	item, err := txn.Get(p.cacheKey(pageID))
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return entity.NewCache(), nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get cache: %w", err)
+	}
+
+	var data entity.CacheData
+
+	if err := item.Value(func(val []byte) error {
+		return unmarshal(val, &data)
+	}); err != nil {
+		return nil, fmt.Errorf("unmarshal cache: %w", err)
+	}
+
+	return entity.NewCacheFromData(data), nil
+}
+
 func (p *Page) Get(_ context.Context, id uuid.UUID) (*entity.Page, error) {
 	page := entity.Page{}
 	page.ID = id
@@ -193,6 +261,13 @@ func (p *Page) ListUnprocessed(ctx context.Context) ([]entity.Page, error) {
 			}
 
 			if page.Status == entity.StatusNew || page.Status == entity.StatusProcessing {
+				cache, err := p.getCache(txn, page.ID)
+				if err != nil {
+					return fmt.Errorf("get page %s cache: %w", page.ID, err)
+				}
+
+				page.SetCache(cache)
+
 				//goland:noinspection GoVetCopyLock
 				pages = append(pages, page) //nolint:govet // didn't touch the lock here
 			}
@@ -216,3 +291,7 @@ func (p *Page) ListUnprocessed(ctx context.Context) ([]entity.Page, error) {
 func (p *Page) key(site *entity.Page) []byte {
 	return append(p.prefix, []byte(site.ID.String())...)
 }
+
+func (p *Page) cacheKey(pageID uuid.UUID) []byte {
+	return append(append([]byte{}, p.cachePrefix...), []byte(pageID.String())...)
+}
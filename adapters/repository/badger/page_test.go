@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/dgraph-io/badger/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
@@ -64,3 +65,60 @@ func TestSite(t *testing.T) {
 		assert.Equal(t, site.Status, all[0].Status)
 	})
 }
+
+func TestPage_Cache(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("skip db test")
+	}
+
+	ctx := context.Background()
+
+	tempDir, err := os.MkdirTemp(os.TempDir(), "badger_test")
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		assert.NoError(t, os.RemoveAll(tempDir))
+	})
+
+	db, err := repository.NewBadger(tempDir, zaptest.NewLogger(t).Named("db"))
+	require.NoError(t, err)
+
+	pageRepo, err := NewPage(db)
+	require.NoError(t, err)
+
+	page := entity.NewPage("https://example.com", "", entity.FormatSingleFile)
+
+	_, err = page.Cache().Write([]byte("<html></html>"))
+	require.NoError(t, err)
+
+	require.NoError(t, pageRepo.Save(ctx, page))
+
+	resource := entity.CachedResource{MimeType: "image/png", FinalURL: "https://example.com/image.png", Data: []byte("png")}
+	page.Cache().SetResource("https://example.com/image.png", resource)
+
+	require.NoError(t, pageRepo.SaveCache(ctx, page.ID, page.Cache()))
+
+	unprocessed, err := pageRepo.ListUnprocessed(ctx)
+	require.NoError(t, err)
+	require.Len(t, unprocessed, 1)
+
+	cache := unprocessed[0].Cache()
+	require.NotNil(t, cache)
+	assert.Equal(t, "<html></html>", string(cache.Get()))
+
+	stored, ok := cache.Resource("https://example.com/image.png")
+	require.True(t, ok)
+	assert.Equal(t, resource, stored)
+
+	page.Status = entity.StatusDone
+	require.NoError(t, pageRepo.Save(ctx, page))
+
+	require.NoError(t, db.View(func(txn *badger.Txn) error {
+		_, err := txn.Get(pageRepo.cacheKey(page.ID))
+		assert.ErrorIs(t, err, badger.ErrKeyNotFound)
+
+		return nil
+	}))
+}
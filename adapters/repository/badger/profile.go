@@ -0,0 +1,136 @@
+package badger
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/google/uuid"
+
+	"github.com/derfenix/webarchive/adapters/repository"
+
+	"github.com/derfenix/webarchive/entity"
+)
+
+func NewProfile(db *badger.DB) (*Profile, error) {
+	return &Profile{
+		db:     db,
+		prefix: []byte("profile:"),
+	}, nil
+}
+
+type Profile struct {
+	db     *badger.DB
+	prefix []byte
+}
+
+func (p *Profile) Save(_ context.Context, profile *entity.Profile) error {
+	if p.db.IsClosed() {
+		return repository.ErrDBClosed
+	}
+
+	marshaled, err := marshal(profile)
+	if err != nil {
+		return fmt.Errorf("marshal data: %w", err)
+	}
+
+	if err := p.db.Update(func(txn *badger.Txn) error {
+		if err := txn.Set(p.key(profile.ID), marshaled); err != nil {
+			return fmt.Errorf("put data: %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("update db: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Profile) Get(_ context.Context, id uuid.UUID) (*entity.Profile, error) {
+	var profile entity.Profile
+
+	err := p.db.View(func(txn *badger.Txn) error {
+		data, err := txn.Get(p.key(id))
+		if err != nil {
+			return fmt.Errorf("get data: %w", err)
+		}
+
+		if err := data.Value(func(val []byte) error {
+			return unmarshal(val, &profile)
+		}); err != nil {
+			return fmt.Errorf("unmarshal data: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("view: %w", err)
+	}
+
+	return &profile, nil
+}
+
+func (p *Profile) Delete(_ context.Context, id uuid.UUID) error {
+	if p.db.IsClosed() {
+		return repository.ErrDBClosed
+	}
+
+	if err := p.db.Update(func(txn *badger.Txn) error {
+		if _, err := txn.Get(p.key(id)); err != nil {
+			return fmt.Errorf("get data: %w", err)
+		}
+
+		if err := txn.Delete(p.key(id)); err != nil {
+			return fmt.Errorf("delete data: %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("update db: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Profile) ListAll(ctx context.Context) ([]*entity.Profile, error) {
+	profiles := make([]*entity.Profile, 0, 10)
+
+	err := p.db.View(func(txn *badger.Txn) error {
+		iterator := txn.NewIterator(badger.DefaultIteratorOptions)
+
+		defer iterator.Close()
+
+		for iterator.Seek(p.prefix); iterator.ValidForPrefix(p.prefix); iterator.Next() {
+			if err := ctx.Err(); err != nil {
+				return fmt.Errorf("context canceled: %w", err)
+			}
+
+			var profile entity.Profile
+
+			if err := iterator.Item().Value(func(val []byte) error {
+				return unmarshal(val, &profile)
+			}); err != nil {
+				return fmt.Errorf("unmarshal: %w", err)
+			}
+
+			profiles = append(profiles, &profile)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("view: %w", err)
+	}
+
+	sort.Slice(profiles, func(i, j int) bool {
+		return profiles[i].Created.Before(profiles[j].Created)
+	})
+
+	return profiles, nil
+}
+
+func (p *Profile) key(id uuid.UUID) []byte {
+	return append(append([]byte{}, p.prefix...), []byte(id.String())...)
+}
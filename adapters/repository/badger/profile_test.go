@@ -0,0 +1,66 @@
+package badger
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/derfenix/webarchive/adapters/repository"
+	"github.com/derfenix/webarchive/entity"
+)
+
+func TestProfile(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("skip db test")
+	}
+
+	ctx := context.Background()
+
+	tempDir, err := os.MkdirTemp(os.TempDir(), "badger_test")
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		assert.NoError(t, os.RemoveAll(tempDir))
+	})
+
+	db, err := repository.NewBadger(tempDir, zaptest.NewLogger(t).Named("db"))
+	require.NoError(t, err)
+
+	profileRepo, err := NewProfile(db)
+	require.NoError(t, err)
+
+	profile := entity.NewProfile("intranet", "intranet.local")
+	profile.Created = profile.Created.Truncate(time.Microsecond)
+	profile.Username = "user"
+	profile.Password = "pass"
+	profile.Headers = map[string]string{"X-Token": "secret"}
+	profile.Cookies = []entity.Cookie{{Name: "session", Value: "abc", Domain: "intranet.local", Path: "/"}}
+
+	require.NoError(t, profileRepo.Save(ctx, profile))
+
+	stored, err := profileRepo.Get(ctx, profile.ID)
+	require.NoError(t, err)
+	assert.Equal(t, profile.Cookies, stored.Cookies)
+	assert.Equal(t, profile.Headers, stored.Headers)
+	assert.Equal(t, profile.Password, stored.Password)
+
+	all, err := profileRepo.ListAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.Equal(t, profile.ID, all[0].ID)
+
+	require.NoError(t, profileRepo.Delete(ctx, profile.ID))
+	assert.ErrorIs(t, profileRepo.Delete(ctx, profile.ID), badger.ErrKeyNotFound)
+
+	all, err = profileRepo.ListAll(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, all)
+}
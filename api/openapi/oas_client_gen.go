@@ -29,6 +29,18 @@ type Invoker interface {
 	//
 	// POST /pages
 	AddPage(ctx context.Context, request OptAddPageReq, params AddPageParams) (AddPageRes, error)
+	// AddProfile invokes addProfile operation.
+	//
+	// Add credential profile.
+	//
+	// POST /profiles
+	AddProfile(ctx context.Context, request *AddProfileReq) (AddProfileRes, error)
+	// DeleteProfile invokes deleteProfile operation.
+	//
+	// Delete credential profile.
+	//
+	// DELETE /profiles/{id}
+	DeleteProfile(ctx context.Context, params DeleteProfileParams) (DeleteProfileRes, error)
 	// GetFile invokes getFile operation.
 	//
 	// Get file content.
@@ -47,6 +59,12 @@ type Invoker interface {
 	//
 	// GET /pages
 	GetPages(ctx context.Context) (Pages, error)
+	// GetProfiles invokes getProfiles operation.
+	//
+	// Get all credential profiles.
+	//
+	// GET /profiles
+	GetProfiles(ctx context.Context) ([]Profile, error)
 }
 
 // Client implements OAS client.
@@ -215,7 +233,10 @@ func (c *Client) sendAddPage(ctx context.Context, request OptAddPageReq, params
 			return e.EncodeArray(func(e uri.Encoder) error {
 				for i, item := range params.Formats {
 					if err := func() error {
-						return e.EncodeValue(conv.StringToString(string(item)))
+						if unwrapped := string(item); true {
+							return e.EncodeValue(conv.StringToString(unwrapped))
+						}
+						return nil
 					}(); err != nil {
 						return errors.Wrapf(err, "[%d]", i)
 					}
@@ -253,6 +274,171 @@ func (c *Client) sendAddPage(ctx context.Context, request OptAddPageReq, params
 	return result, nil
 }
 
+// AddProfile invokes addProfile operation.
+//
+// Add credential profile.
+//
+// POST /profiles
+func (c *Client) AddProfile(ctx context.Context, request *AddProfileReq) (AddProfileRes, error) {
+	res, err := c.sendAddProfile(ctx, request)
+	return res, err
+}
+
+func (c *Client) sendAddProfile(ctx context.Context, request *AddProfileReq) (res AddProfileRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("addProfile"),
+		semconv.HTTPMethodKey.String("POST"),
+		semconv.HTTPRouteKey.String("/profiles"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(float64(elapsedDuration)/float64(time.Millisecond)), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, "AddProfile",
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/profiles"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeAddProfileRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeAddProfileResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
+// DeleteProfile invokes deleteProfile operation.
+//
+// Delete credential profile.
+//
+// DELETE /profiles/{id}
+func (c *Client) DeleteProfile(ctx context.Context, params DeleteProfileParams) (DeleteProfileRes, error) {
+	res, err := c.sendDeleteProfile(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendDeleteProfile(ctx context.Context, params DeleteProfileParams) (res DeleteProfileRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("deleteProfile"),
+		semconv.HTTPMethodKey.String("DELETE"),
+		semconv.HTTPRouteKey.String("/profiles/{id}"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(float64(elapsedDuration)/float64(time.Millisecond)), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, "DeleteProfile",
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [2]string
+	pathParts[0] = "/profiles/"
+	{
+		// Encode "id" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "id",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			return e.EncodeValue(conv.UUIDToString(params.ID))
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "DELETE", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeDeleteProfileResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
 // GetFile invokes getFile operation.
 //
 // Get file content.
@@ -523,3 +709,75 @@ func (c *Client) sendGetPages(ctx context.Context) (res Pages, err error) {
 
 	return result, nil
 }
+
+// GetProfiles invokes getProfiles operation.
+//
+// Get all credential profiles.
+//
+// GET /profiles
+func (c *Client) GetProfiles(ctx context.Context) ([]Profile, error) {
+	res, err := c.sendGetProfiles(ctx)
+	return res, err
+}
+
+func (c *Client) sendGetProfiles(ctx context.Context) (res []Profile, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("getProfiles"),
+		semconv.HTTPMethodKey.String("GET"),
+		semconv.HTTPRouteKey.String("/profiles"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(float64(elapsedDuration)/float64(time.Millisecond)), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, "GetProfiles",
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/profiles"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeGetProfilesResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
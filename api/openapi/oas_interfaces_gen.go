@@ -5,6 +5,14 @@ type AddPageRes interface {
 	addPageRes()
 }
 
+type AddProfileRes interface {
+	addProfileRes()
+}
+
+type DeleteProfileRes interface {
+	deleteProfileRes()
+}
+
 type GetFileRes interface {
 	getFileRes()
 }
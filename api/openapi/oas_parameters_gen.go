@@ -3,7 +3,6 @@
 package openapi
 
 import (
-	"fmt"
 	"net/http"
 	"net/url"
 
@@ -152,17 +151,24 @@ func decodeAddPageParams(args [0]string, argsEscaped bool, r *http.Request) (par
 				return d.DecodeArray(func(d uri.Decoder) error {
 					var paramsDotFormatsVal Format
 					if err := func() error {
-						val, err := d.DecodeValue()
-						if err != nil {
-							return err
-						}
+						var paramsDotFormatsValVal string
+						if err := func() error {
+							val, err := d.DecodeValue()
+							if err != nil {
+								return err
+							}
+
+							c, err := conv.ToString(val)
+							if err != nil {
+								return err
+							}
 
-						c, err := conv.ToString(val)
-						if err != nil {
+							paramsDotFormatsValVal = c
+							return nil
+						}(); err != nil {
 							return err
 						}
-
-						paramsDotFormatsVal = Format(c)
+						paramsDotFormatsVal = Format(paramsDotFormatsValVal)
 						return nil
 					}(); err != nil {
 						return err
@@ -173,34 +179,77 @@ func decodeAddPageParams(args [0]string, argsEscaped bool, r *http.Request) (par
 			}); err != nil {
 				return err
 			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "formats",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// DeleteProfileParams is parameters of deleteProfile operation.
+type DeleteProfileParams struct {
+	ID uuid.UUID
+}
+
+func unpackDeleteProfileParams(packed middleware.Parameters) (params DeleteProfileParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "id",
+			In:   "path",
+		}
+		params.ID = packed[key].(uuid.UUID)
+	}
+	return params
+}
+
+func decodeDeleteProfileParams(args [1]string, argsEscaped bool, r *http.Request) (params DeleteProfileParams, _ error) {
+	// Decode path: id.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "id",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
 			if err := func() error {
-				var failures []validate.FieldError
-				for i, elem := range params.Formats {
-					if err := func() error {
-						if err := elem.Validate(); err != nil {
-							return err
-						}
-						return nil
-					}(); err != nil {
-						failures = append(failures, validate.FieldError{
-							Name:  fmt.Sprintf("[%d]", i),
-							Error: err,
-						})
-					}
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
 				}
-				if len(failures) > 0 {
-					return &validate.Error{Fields: failures}
+
+				c, err := conv.ToUUID(val)
+				if err != nil {
+					return err
 				}
+
+				params.ID = c
 				return nil
 			}(); err != nil {
 				return err
 			}
+		} else {
+			return validate.ErrFieldRequired
 		}
 		return nil
 	}(); err != nil {
 		return params, &ogenerrors.DecodeParamError{
-			Name: "formats",
-			In:   "query",
+			Name: "id",
+			In:   "path",
 			Err:  err,
 		}
 	}
@@ -49,77 +49,135 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			break
 		}
 		switch elem[0] {
-		case '/': // Prefix: "/pages"
-			if l := len("/pages"); len(elem) >= l && elem[0:l] == "/pages" {
+		case '/': // Prefix: "/p"
+			if l := len("/p"); len(elem) >= l && elem[0:l] == "/p" {
 				elem = elem[l:]
 			} else {
 				break
 			}
 
 			if len(elem) == 0 {
-				switch r.Method {
-				case "GET":
-					s.handleGetPagesRequest([0]string{}, elemIsEscaped, w, r)
-				case "POST":
-					s.handleAddPageRequest([0]string{}, elemIsEscaped, w, r)
-				default:
-					s.notAllowed(w, r, "GET,POST")
-				}
-
-				return
+				break
 			}
 			switch elem[0] {
-			case '/': // Prefix: "/"
-				if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+			case 'a': // Prefix: "ages"
+				if l := len("ages"); len(elem) >= l && elem[0:l] == "ages" {
 					elem = elem[l:]
 				} else {
 					break
 				}
 
-				// Param: "id"
-				// Match until "/"
-				idx := strings.IndexByte(elem, '/')
-				if idx < 0 {
-					idx = len(elem)
+				if len(elem) == 0 {
+					switch r.Method {
+					case "GET":
+						s.handleGetPagesRequest([0]string{}, elemIsEscaped, w, r)
+					case "POST":
+						s.handleAddPageRequest([0]string{}, elemIsEscaped, w, r)
+					default:
+						s.notAllowed(w, r, "GET,POST")
+					}
+
+					return
+				}
+				switch elem[0] {
+				case '/': // Prefix: "/"
+					if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					// Param: "id"
+					// Match until "/"
+					idx := strings.IndexByte(elem, '/')
+					if idx < 0 {
+						idx = len(elem)
+					}
+					args[0] = elem[:idx]
+					elem = elem[idx:]
+
+					if len(elem) == 0 {
+						switch r.Method {
+						case "GET":
+							s.handleGetPageRequest([1]string{
+								args[0],
+							}, elemIsEscaped, w, r)
+						default:
+							s.notAllowed(w, r, "GET")
+						}
+
+						return
+					}
+					switch elem[0] {
+					case '/': // Prefix: "/file/"
+						if l := len("/file/"); len(elem) >= l && elem[0:l] == "/file/" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						// Param: "file_id"
+						// Leaf parameter
+						args[1] = elem
+						elem = ""
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "GET":
+								s.handleGetFileRequest([2]string{
+									args[0],
+									args[1],
+								}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "GET")
+							}
+
+							return
+						}
+					}
+				}
+			case 'r': // Prefix: "rofiles"
+				if l := len("rofiles"); len(elem) >= l && elem[0:l] == "rofiles" {
+					elem = elem[l:]
+				} else {
+					break
 				}
-				args[0] = elem[:idx]
-				elem = elem[idx:]
 
 				if len(elem) == 0 {
 					switch r.Method {
 					case "GET":
-						s.handleGetPageRequest([1]string{
-							args[0],
-						}, elemIsEscaped, w, r)
+						s.handleGetProfilesRequest([0]string{}, elemIsEscaped, w, r)
+					case "POST":
+						s.handleAddProfileRequest([0]string{}, elemIsEscaped, w, r)
 					default:
-						s.notAllowed(w, r, "GET")
+						s.notAllowed(w, r, "GET,POST")
 					}
 
 					return
 				}
 				switch elem[0] {
-				case '/': // Prefix: "/file/"
-					if l := len("/file/"); len(elem) >= l && elem[0:l] == "/file/" {
+				case '/': // Prefix: "/"
+					if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
 						elem = elem[l:]
 					} else {
 						break
 					}
 
-					// Param: "file_id"
+					// Param: "id"
 					// Leaf parameter
-					args[1] = elem
+					args[0] = elem
 					elem = ""
 
 					if len(elem) == 0 {
 						// Leaf node.
 						switch r.Method {
-						case "GET":
-							s.handleGetFileRequest([2]string{
+						case "DELETE":
+							s.handleDeleteProfileRequest([1]string{
 								args[0],
-								args[1],
 							}, elemIsEscaped, w, r)
 						default:
-							s.notAllowed(w, r, "GET")
+							s.notAllowed(w, r, "DELETE")
 						}
 
 						return
@@ -206,89 +264,159 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 			break
 		}
 		switch elem[0] {
-		case '/': // Prefix: "/pages"
-			if l := len("/pages"); len(elem) >= l && elem[0:l] == "/pages" {
+		case '/': // Prefix: "/p"
+			if l := len("/p"); len(elem) >= l && elem[0:l] == "/p" {
 				elem = elem[l:]
 			} else {
 				break
 			}
 
 			if len(elem) == 0 {
-				switch method {
-				case "GET":
-					r.name = "GetPages"
-					r.summary = "Get all pages"
-					r.operationID = "getPages"
-					r.pathPattern = "/pages"
-					r.args = args
-					r.count = 0
-					return r, true
-				case "POST":
-					r.name = "AddPage"
-					r.summary = "Add new page"
-					r.operationID = "addPage"
-					r.pathPattern = "/pages"
-					r.args = args
-					r.count = 0
-					return r, true
-				default:
-					return
-				}
+				break
 			}
 			switch elem[0] {
-			case '/': // Prefix: "/"
-				if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+			case 'a': // Prefix: "ages"
+				if l := len("ages"); len(elem) >= l && elem[0:l] == "ages" {
 					elem = elem[l:]
 				} else {
 					break
 				}
 
-				// Param: "id"
-				// Match until "/"
-				idx := strings.IndexByte(elem, '/')
-				if idx < 0 {
-					idx = len(elem)
+				if len(elem) == 0 {
+					switch method {
+					case "GET":
+						r.name = "GetPages"
+						r.summary = "Get all pages"
+						r.operationID = "getPages"
+						r.pathPattern = "/pages"
+						r.args = args
+						r.count = 0
+						return r, true
+					case "POST":
+						r.name = "AddPage"
+						r.summary = "Add new page"
+						r.operationID = "addPage"
+						r.pathPattern = "/pages"
+						r.args = args
+						r.count = 0
+						return r, true
+					default:
+						return
+					}
+				}
+				switch elem[0] {
+				case '/': // Prefix: "/"
+					if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					// Param: "id"
+					// Match until "/"
+					idx := strings.IndexByte(elem, '/')
+					if idx < 0 {
+						idx = len(elem)
+					}
+					args[0] = elem[:idx]
+					elem = elem[idx:]
+
+					if len(elem) == 0 {
+						switch method {
+						case "GET":
+							r.name = "GetPage"
+							r.summary = ""
+							r.operationID = "getPage"
+							r.pathPattern = "/pages/{id}"
+							r.args = args
+							r.count = 1
+							return r, true
+						default:
+							return
+						}
+					}
+					switch elem[0] {
+					case '/': // Prefix: "/file/"
+						if l := len("/file/"); len(elem) >= l && elem[0:l] == "/file/" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						// Param: "file_id"
+						// Leaf parameter
+						args[1] = elem
+						elem = ""
+
+						if len(elem) == 0 {
+							switch method {
+							case "GET":
+								// Leaf: GetFile
+								r.name = "GetFile"
+								r.summary = ""
+								r.operationID = "getFile"
+								r.pathPattern = "/pages/{id}/file/{file_id}"
+								r.args = args
+								r.count = 2
+								return r, true
+							default:
+								return
+							}
+						}
+					}
+				}
+			case 'r': // Prefix: "rofiles"
+				if l := len("rofiles"); len(elem) >= l && elem[0:l] == "rofiles" {
+					elem = elem[l:]
+				} else {
+					break
 				}
-				args[0] = elem[:idx]
-				elem = elem[idx:]
 
 				if len(elem) == 0 {
 					switch method {
 					case "GET":
-						r.name = "GetPage"
-						r.summary = ""
-						r.operationID = "getPage"
-						r.pathPattern = "/pages/{id}"
+						r.name = "GetProfiles"
+						r.summary = "Get all credential profiles"
+						r.operationID = "getProfiles"
+						r.pathPattern = "/profiles"
 						r.args = args
-						r.count = 1
+						r.count = 0
+						return r, true
+					case "POST":
+						r.name = "AddProfile"
+						r.summary = "Add credential profile"
+						r.operationID = "addProfile"
+						r.pathPattern = "/profiles"
+						r.args = args
+						r.count = 0
 						return r, true
 					default:
 						return
 					}
 				}
 				switch elem[0] {
-				case '/': // Prefix: "/file/"
-					if l := len("/file/"); len(elem) >= l && elem[0:l] == "/file/" {
+				case '/': // Prefix: "/"
+					if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
 						elem = elem[l:]
 					} else {
 						break
 					}
 
-					// Param: "file_id"
+					// Param: "id"
 					// Leaf parameter
-					args[1] = elem
+					args[0] = elem
 					elem = ""
 
 					if len(elem) == 0 {
 						switch method {
-						case "GET":
-							// Leaf: GetFile
-							r.name = "GetFile"
+						case "DELETE":
+							// Leaf: DeleteProfile
+							r.name = "DeleteProfile"
 							r.summary = ""
-							r.operationID = "getFile"
-							r.pathPattern = "/pages/{id}/file/{file_id}"
+							r.operationID = "deleteProfile"
+							r.pathPattern = "/profiles/{id}"
 							r.args = args
-							r.count = 2
+							r.count = 1
 							return r, true
 						default:
 							return
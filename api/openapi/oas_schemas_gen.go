@@ -43,9 +43,10 @@ func (s *AddPageBadRequest) SetError(val string) {
 func (*AddPageBadRequest) addPageRes() {}
 
 type AddPageReq struct {
-	URL         string    `json:"url"`
-	Description OptString `json:"description"`
-	Formats     []Format  `json:"formats"`
+	URL         string         `json:"url"`
+	Description OptString      `json:"description"`
+	Formats     []Format       `json:"formats"`
+	Options     OptPageOptions `json:"options"`
 }
 
 // GetURL returns the value of URL.
@@ -63,6 +64,11 @@ func (s *AddPageReq) GetFormats() []Format {
 	return s.Formats
 }
 
+// GetOptions returns the value of Options.
+func (s *AddPageReq) GetOptions() OptPageOptions {
+	return s.Options
+}
+
 // SetURL sets the value of URL.
 func (s *AddPageReq) SetURL(val string) {
 	s.URL = val
@@ -78,6 +84,157 @@ func (s *AddPageReq) SetFormats(val []Format) {
 	s.Formats = val
 }
 
+// SetOptions sets the value of Options.
+func (s *AddPageReq) SetOptions(val OptPageOptions) {
+	s.Options = val
+}
+
+type AddProfileBadRequest struct {
+	Field string `json:"field"`
+	Error string `json:"error"`
+}
+
+// GetField returns the value of Field.
+func (s *AddProfileBadRequest) GetField() string {
+	return s.Field
+}
+
+// GetError returns the value of Error.
+func (s *AddProfileBadRequest) GetError() string {
+	return s.Error
+}
+
+// SetField sets the value of Field.
+func (s *AddProfileBadRequest) SetField(val string) {
+	s.Field = val
+}
+
+// SetError sets the value of Error.
+func (s *AddProfileBadRequest) SetError(val string) {
+	s.Error = val
+}
+
+func (*AddProfileBadRequest) addProfileRes() {}
+
+type AddProfileReq struct {
+	Name string `json:"name"`
+	// Host to apply the profile to, with its subdomains. `*.example.com` matches subdomains only.
+	Domain string `json:"domain"`
+	// Content of Netscape cookies.txt file.
+	Cookies OptString               `json:"cookies"`
+	Headers OptAddProfileReqHeaders `json:"headers"`
+	// HTTP basic authentication username.
+	Username OptString `json:"username"`
+	Password OptString `json:"password"`
+}
+
+// GetName returns the value of Name.
+func (s *AddProfileReq) GetName() string {
+	return s.Name
+}
+
+// GetDomain returns the value of Domain.
+func (s *AddProfileReq) GetDomain() string {
+	return s.Domain
+}
+
+// GetCookies returns the value of Cookies.
+func (s *AddProfileReq) GetCookies() OptString {
+	return s.Cookies
+}
+
+// GetHeaders returns the value of Headers.
+func (s *AddProfileReq) GetHeaders() OptAddProfileReqHeaders {
+	return s.Headers
+}
+
+// GetUsername returns the value of Username.
+func (s *AddProfileReq) GetUsername() OptString {
+	return s.Username
+}
+
+// GetPassword returns the value of Password.
+func (s *AddProfileReq) GetPassword() OptString {
+	return s.Password
+}
+
+// SetName sets the value of Name.
+func (s *AddProfileReq) SetName(val string) {
+	s.Name = val
+}
+
+// SetDomain sets the value of Domain.
+func (s *AddProfileReq) SetDomain(val string) {
+	s.Domain = val
+}
+
+// SetCookies sets the value of Cookies.
+func (s *AddProfileReq) SetCookies(val OptString) {
+	s.Cookies = val
+}
+
+// SetHeaders sets the value of Headers.
+func (s *AddProfileReq) SetHeaders(val OptAddProfileReqHeaders) {
+	s.Headers = val
+}
+
+// SetUsername sets the value of Username.
+func (s *AddProfileReq) SetUsername(val OptString) {
+	s.Username = val
+}
+
+// SetPassword sets the value of Password.
+func (s *AddProfileReq) SetPassword(val OptString) {
+	s.Password = val
+}
+
+type AddProfileReqHeaders map[string]string
+
+func (s *AddProfileReqHeaders) init() AddProfileReqHeaders {
+	m := *s
+	if m == nil {
+		m = map[string]string{}
+		*s = m
+	}
+	return m
+}
+
+// Ref: #/components/schemas/attempt
+type Attempt struct {
+	Started time.Time `json:"started"`
+	Error   OptString `json:"error"`
+}
+
+// GetStarted returns the value of Started.
+func (s *Attempt) GetStarted() time.Time {
+	return s.Started
+}
+
+// GetError returns the value of Error.
+func (s *Attempt) GetError() OptString {
+	return s.Error
+}
+
+// SetStarted sets the value of Started.
+func (s *Attempt) SetStarted(val time.Time) {
+	s.Started = val
+}
+
+// SetError sets the value of Error.
+func (s *Attempt) SetError(val OptString) {
+	s.Error = val
+}
+
+// DeleteProfileNoContent is response for DeleteProfile operation.
+type DeleteProfileNoContent struct{}
+
+func (*DeleteProfileNoContent) deleteProfileRes() {}
+
+// DeleteProfileNotFound is response for DeleteProfile operation.
+type DeleteProfileNotFound struct{}
+
+func (*DeleteProfileNotFound) deleteProfileRes() {}
+
 // Ref: #/components/schemas/error
 type Error struct {
 	Message   string    `json:"message"`
@@ -130,66 +287,44 @@ func (s *ErrorStatusCode) SetResponse(val Error) {
 	s.Response = val
 }
 
-// Ref: #/components/schemas/format
 type Format string
 
-const (
-	FormatAll        Format = "all"
-	FormatPdf        Format = "pdf"
-	FormatSingleFile Format = "single_file"
-	FormatHeaders    Format = "headers"
-)
+// GetFileNotFound is response for GetFile operation.
+type GetFileNotFound struct{}
 
-// AllValues returns all Format values.
-func (Format) AllValues() []Format {
-	return []Format{
-		FormatAll,
-		FormatPdf,
-		FormatSingleFile,
-		FormatHeaders,
-	}
+func (*GetFileNotFound) getFileRes() {}
+
+type GetFileOKApplicationEpubZip struct {
+	Data io.Reader
 }
 
-// MarshalText implements encoding.TextMarshaler.
-func (s Format) MarshalText() ([]byte, error) {
-	switch s {
-	case FormatAll:
-		return []byte(s), nil
-	case FormatPdf:
-		return []byte(s), nil
-	case FormatSingleFile:
-		return []byte(s), nil
-	case FormatHeaders:
-		return []byte(s), nil
-	default:
-		return nil, errors.Errorf("invalid value: %q", s)
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s GetFileOKApplicationEpubZip) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
 	}
+	return s.Data.Read(p)
 }
 
-// UnmarshalText implements encoding.TextUnmarshaler.
-func (s *Format) UnmarshalText(data []byte) error {
-	switch Format(data) {
-	case FormatAll:
-		*s = FormatAll
-		return nil
-	case FormatPdf:
-		*s = FormatPdf
-		return nil
-	case FormatSingleFile:
-		*s = FormatSingleFile
-		return nil
-	case FormatHeaders:
-		*s = FormatHeaders
-		return nil
-	default:
-		return errors.Errorf("invalid value: %q", data)
-	}
+func (*GetFileOKApplicationEpubZip) getFileRes() {}
+
+type GetFileOKApplicationOctetStream struct {
+	Data io.Reader
 }
 
-// GetFileNotFound is response for GetFile operation.
-type GetFileNotFound struct{}
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s GetFileOKApplicationOctetStream) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
 
-func (*GetFileNotFound) getFileRes() {}
+func (*GetFileOKApplicationOctetStream) getFileRes() {}
 
 type GetFileOKApplicationPdf struct {
 	Data io.Reader
@@ -207,6 +342,70 @@ func (s GetFileOKApplicationPdf) Read(p []byte) (n int, err error) {
 
 func (*GetFileOKApplicationPdf) getFileRes() {}
 
+type GetFileOKApplicationWarc struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s GetFileOKApplicationWarc) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+func (*GetFileOKApplicationWarc) getFileRes() {}
+
+type GetFileOKApplicationZip struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s GetFileOKApplicationZip) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+func (*GetFileOKApplicationZip) getFileRes() {}
+
+type GetFileOKImageJpeg struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s GetFileOKImageJpeg) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+func (*GetFileOKImageJpeg) getFileRes() {}
+
+type GetFileOKImagePNG struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s GetFileOKImagePNG) Read(p []byte) (n int, err error) {
+	if s.Data == nil {
+		return 0, io.EOF
+	}
+	return s.Data.Read(p)
+}
+
+func (*GetFileOKImagePNG) getFileRes() {}
+
 type GetFileOKTextHTML struct {
 	Data io.Reader
 }
@@ -290,38 +489,38 @@ func (o OptAddPageReq) Or(d AddPageReq) AddPageReq {
 	return d
 }
 
-// NewOptString returns new OptString with value set to v.
-func NewOptString(v string) OptString {
-	return OptString{
+// NewOptAddProfileReqHeaders returns new OptAddProfileReqHeaders with value set to v.
+func NewOptAddProfileReqHeaders(v AddProfileReqHeaders) OptAddProfileReqHeaders {
+	return OptAddProfileReqHeaders{
 		Value: v,
 		Set:   true,
 	}
 }
 
-// OptString is optional string.
-type OptString struct {
-	Value string
+// OptAddProfileReqHeaders is optional AddProfileReqHeaders.
+type OptAddProfileReqHeaders struct {
+	Value AddProfileReqHeaders
 	Set   bool
 }
 
-// IsSet returns true if OptString was set.
-func (o OptString) IsSet() bool { return o.Set }
+// IsSet returns true if OptAddProfileReqHeaders was set.
+func (o OptAddProfileReqHeaders) IsSet() bool { return o.Set }
 
 // Reset unsets value.
-func (o *OptString) Reset() {
-	var v string
+func (o *OptAddProfileReqHeaders) Reset() {
+	var v AddProfileReqHeaders
 	o.Value = v
 	o.Set = false
 }
 
 // SetTo sets value to v.
-func (o *OptString) SetTo(v string) {
+func (o *OptAddProfileReqHeaders) SetTo(v AddProfileReqHeaders) {
 	o.Set = true
 	o.Value = v
 }
 
 // Get returns value and boolean that denotes whether value was set.
-func (o OptString) Get() (v string, ok bool) {
+func (o OptAddProfileReqHeaders) Get() (v AddProfileReqHeaders, ok bool) {
 	if !o.Set {
 		return v, false
 	}
@@ -329,131 +528,888 @@ func (o OptString) Get() (v string, ok bool) {
 }
 
 // Or returns value if set, or given parameter if does not.
-func (o OptString) Or(d string) string {
+func (o OptAddProfileReqHeaders) Or(d AddProfileReqHeaders) AddProfileReqHeaders {
 	if v, ok := o.Get(); ok {
 		return v
 	}
 	return d
 }
 
-// Ref: #/components/schemas/page
-type Page struct {
-	ID      uuid.UUID `json:"id"`
-	URL     string    `json:"url"`
-	Created time.Time `json:"created"`
-	Formats []Format  `json:"formats"`
-	Status  Status    `json:"status"`
-	Meta    PageMeta  `json:"meta"`
+// NewOptBool returns new OptBool with value set to v.
+func NewOptBool(v bool) OptBool {
+	return OptBool{
+		Value: v,
+		Set:   true,
+	}
 }
 
-// GetID returns the value of ID.
-func (s *Page) GetID() uuid.UUID {
-	return s.ID
+// OptBool is optional bool.
+type OptBool struct {
+	Value bool
+	Set   bool
 }
 
-// GetURL returns the value of URL.
-func (s *Page) GetURL() string {
-	return s.URL
-}
+// IsSet returns true if OptBool was set.
+func (o OptBool) IsSet() bool { return o.Set }
 
-// GetCreated returns the value of Created.
-func (s *Page) GetCreated() time.Time {
-	return s.Created
+// Reset unsets value.
+func (o *OptBool) Reset() {
+	var v bool
+	o.Value = v
+	o.Set = false
 }
 
-// GetFormats returns the value of Formats.
-func (s *Page) GetFormats() []Format {
-	return s.Formats
+// SetTo sets value to v.
+func (o *OptBool) SetTo(v bool) {
+	o.Set = true
+	o.Value = v
 }
 
-// GetStatus returns the value of Status.
-func (s *Page) GetStatus() Status {
-	return s.Status
+// Get returns value and boolean that denotes whether value was set.
+func (o OptBool) Get() (v bool, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
 }
 
-// GetMeta returns the value of Meta.
-func (s *Page) GetMeta() PageMeta {
-	return s.Meta
+// Or returns value if set, or given parameter if does not.
+func (o OptBool) Or(d bool) bool {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
 }
 
-// SetID sets the value of ID.
-func (s *Page) SetID(val uuid.UUID) {
-	s.ID = val
+// NewOptDateTime returns new OptDateTime with value set to v.
+func NewOptDateTime(v time.Time) OptDateTime {
+	return OptDateTime{
+		Value: v,
+		Set:   true,
+	}
 }
 
-// SetURL sets the value of URL.
-func (s *Page) SetURL(val string) {
-	s.URL = val
+// OptDateTime is optional time.Time.
+type OptDateTime struct {
+	Value time.Time
+	Set   bool
 }
 
-// SetCreated sets the value of Created.
-func (s *Page) SetCreated(val time.Time) {
-	s.Created = val
+// IsSet returns true if OptDateTime was set.
+func (o OptDateTime) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptDateTime) Reset() {
+	var v time.Time
+	o.Value = v
+	o.Set = false
 }
 
-// SetFormats sets the value of Formats.
-func (s *Page) SetFormats(val []Format) {
-	s.Formats = val
+// SetTo sets value to v.
+func (o *OptDateTime) SetTo(v time.Time) {
+	o.Set = true
+	o.Value = v
 }
 
-// SetStatus sets the value of Status.
-func (s *Page) SetStatus(val Status) {
+// Get returns value and boolean that denotes whether value was set.
+func (o OptDateTime) Get() (v time.Time, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptDateTime) Or(d time.Time) time.Time {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptFloat64 returns new OptFloat64 with value set to v.
+func NewOptFloat64(v float64) OptFloat64 {
+	return OptFloat64{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptFloat64 is optional float64.
+type OptFloat64 struct {
+	Value float64
+	Set   bool
+}
+
+// IsSet returns true if OptFloat64 was set.
+func (o OptFloat64) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptFloat64) Reset() {
+	var v float64
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptFloat64) SetTo(v float64) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptFloat64) Get() (v float64, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptFloat64) Or(d float64) float64 {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptPageMetaOpengraph returns new OptPageMetaOpengraph with value set to v.
+func NewOptPageMetaOpengraph(v PageMetaOpengraph) OptPageMetaOpengraph {
+	return OptPageMetaOpengraph{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptPageMetaOpengraph is optional PageMetaOpengraph.
+type OptPageMetaOpengraph struct {
+	Value PageMetaOpengraph
+	Set   bool
+}
+
+// IsSet returns true if OptPageMetaOpengraph was set.
+func (o OptPageMetaOpengraph) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptPageMetaOpengraph) Reset() {
+	var v PageMetaOpengraph
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptPageMetaOpengraph) SetTo(v PageMetaOpengraph) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptPageMetaOpengraph) Get() (v PageMetaOpengraph, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptPageMetaOpengraph) Or(d PageMetaOpengraph) PageMetaOpengraph {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptPageMetaTwitter returns new OptPageMetaTwitter with value set to v.
+func NewOptPageMetaTwitter(v PageMetaTwitter) OptPageMetaTwitter {
+	return OptPageMetaTwitter{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptPageMetaTwitter is optional PageMetaTwitter.
+type OptPageMetaTwitter struct {
+	Value PageMetaTwitter
+	Set   bool
+}
+
+// IsSet returns true if OptPageMetaTwitter was set.
+func (o OptPageMetaTwitter) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptPageMetaTwitter) Reset() {
+	var v PageMetaTwitter
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptPageMetaTwitter) SetTo(v PageMetaTwitter) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptPageMetaTwitter) Get() (v PageMetaTwitter, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptPageMetaTwitter) Or(d PageMetaTwitter) PageMetaTwitter {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptPageOptions returns new OptPageOptions with value set to v.
+func NewOptPageOptions(v PageOptions) OptPageOptions {
+	return OptPageOptions{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptPageOptions is optional PageOptions.
+type OptPageOptions struct {
+	Value PageOptions
+	Set   bool
+}
+
+// IsSet returns true if OptPageOptions was set.
+func (o OptPageOptions) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptPageOptions) Reset() {
+	var v PageOptions
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptPageOptions) SetTo(v PageOptions) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptPageOptions) Get() (v PageOptions, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptPageOptions) Or(d PageOptions) PageOptions {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptPdfOptions returns new OptPdfOptions with value set to v.
+func NewOptPdfOptions(v PdfOptions) OptPdfOptions {
+	return OptPdfOptions{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptPdfOptions is optional PdfOptions.
+type OptPdfOptions struct {
+	Value PdfOptions
+	Set   bool
+}
+
+// IsSet returns true if OptPdfOptions was set.
+func (o OptPdfOptions) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptPdfOptions) Reset() {
+	var v PdfOptions
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptPdfOptions) SetTo(v PdfOptions) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptPdfOptions) Get() (v PdfOptions, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptPdfOptions) Or(d PdfOptions) PdfOptions {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptPdfOptionsMediaType returns new OptPdfOptionsMediaType with value set to v.
+func NewOptPdfOptionsMediaType(v PdfOptionsMediaType) OptPdfOptionsMediaType {
+	return OptPdfOptionsMediaType{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptPdfOptionsMediaType is optional PdfOptionsMediaType.
+type OptPdfOptionsMediaType struct {
+	Value PdfOptionsMediaType
+	Set   bool
+}
+
+// IsSet returns true if OptPdfOptionsMediaType was set.
+func (o OptPdfOptionsMediaType) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptPdfOptionsMediaType) Reset() {
+	var v PdfOptionsMediaType
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptPdfOptionsMediaType) SetTo(v PdfOptionsMediaType) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptPdfOptionsMediaType) Get() (v PdfOptionsMediaType, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptPdfOptionsMediaType) Or(d PdfOptionsMediaType) PdfOptionsMediaType {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptPdfOptionsPageSize returns new OptPdfOptionsPageSize with value set to v.
+func NewOptPdfOptionsPageSize(v PdfOptionsPageSize) OptPdfOptionsPageSize {
+	return OptPdfOptionsPageSize{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptPdfOptionsPageSize is optional PdfOptionsPageSize.
+type OptPdfOptionsPageSize struct {
+	Value PdfOptionsPageSize
+	Set   bool
+}
+
+// IsSet returns true if OptPdfOptionsPageSize was set.
+func (o OptPdfOptionsPageSize) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptPdfOptionsPageSize) Reset() {
+	var v PdfOptionsPageSize
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptPdfOptionsPageSize) SetTo(v PdfOptionsPageSize) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptPdfOptionsPageSize) Get() (v PdfOptionsPageSize, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptPdfOptionsPageSize) Or(d PdfOptionsPageSize) PdfOptionsPageSize {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptSingleFileOptions returns new OptSingleFileOptions with value set to v.
+func NewOptSingleFileOptions(v SingleFileOptions) OptSingleFileOptions {
+	return OptSingleFileOptions{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptSingleFileOptions is optional SingleFileOptions.
+type OptSingleFileOptions struct {
+	Value SingleFileOptions
+	Set   bool
+}
+
+// IsSet returns true if OptSingleFileOptions was set.
+func (o OptSingleFileOptions) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptSingleFileOptions) Reset() {
+	var v SingleFileOptions
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptSingleFileOptions) SetTo(v SingleFileOptions) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptSingleFileOptions) Get() (v SingleFileOptions, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptSingleFileOptions) Or(d SingleFileOptions) SingleFileOptions {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptString returns new OptString with value set to v.
+func NewOptString(v string) OptString {
+	return OptString{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptString is optional string.
+type OptString struct {
+	Value string
+	Set   bool
+}
+
+// IsSet returns true if OptString was set.
+func (o OptString) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptString) Reset() {
+	var v string
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptString) SetTo(v string) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptString) Get() (v string, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptString) Or(d string) string {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptUUID returns new OptUUID with value set to v.
+func NewOptUUID(v uuid.UUID) OptUUID {
+	return OptUUID{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptUUID is optional uuid.UUID.
+type OptUUID struct {
+	Value uuid.UUID
+	Set   bool
+}
+
+// IsSet returns true if OptUUID was set.
+func (o OptUUID) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptUUID) Reset() {
+	var v uuid.UUID
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptUUID) SetTo(v uuid.UUID) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptUUID) Get() (v uuid.UUID, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptUUID) Or(d uuid.UUID) uuid.UUID {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// Ref: #/components/schemas/page
+type Page struct {
+	ID      uuid.UUID `json:"id"`
+	URL     string    `json:"url"`
+	Created time.Time `json:"created"`
+	Formats []Format  `json:"formats"`
+	Status  Status    `json:"status"`
+	// ID of the page preview image file.
+	Thumbnail OptUUID        `json:"thumbnail"`
+	Options   OptPageOptions `json:"options"`
+	Meta      PageMeta       `json:"meta"`
+}
+
+// GetID returns the value of ID.
+func (s *Page) GetID() uuid.UUID {
+	return s.ID
+}
+
+// GetURL returns the value of URL.
+func (s *Page) GetURL() string {
+	return s.URL
+}
+
+// GetCreated returns the value of Created.
+func (s *Page) GetCreated() time.Time {
+	return s.Created
+}
+
+// GetFormats returns the value of Formats.
+func (s *Page) GetFormats() []Format {
+	return s.Formats
+}
+
+// GetStatus returns the value of Status.
+func (s *Page) GetStatus() Status {
+	return s.Status
+}
+
+// GetThumbnail returns the value of Thumbnail.
+func (s *Page) GetThumbnail() OptUUID {
+	return s.Thumbnail
+}
+
+// GetOptions returns the value of Options.
+func (s *Page) GetOptions() OptPageOptions {
+	return s.Options
+}
+
+// GetMeta returns the value of Meta.
+func (s *Page) GetMeta() PageMeta {
+	return s.Meta
+}
+
+// SetID sets the value of ID.
+func (s *Page) SetID(val uuid.UUID) {
+	s.ID = val
+}
+
+// SetURL sets the value of URL.
+func (s *Page) SetURL(val string) {
+	s.URL = val
+}
+
+// SetCreated sets the value of Created.
+func (s *Page) SetCreated(val time.Time) {
+	s.Created = val
+}
+
+// SetFormats sets the value of Formats.
+func (s *Page) SetFormats(val []Format) {
+	s.Formats = val
+}
+
+// SetStatus sets the value of Status.
+func (s *Page) SetStatus(val Status) {
 	s.Status = val
 }
 
-// SetMeta sets the value of Meta.
-func (s *Page) SetMeta(val PageMeta) {
-	s.Meta = val
+// SetThumbnail sets the value of Thumbnail.
+func (s *Page) SetThumbnail(val OptUUID) {
+	s.Thumbnail = val
+}
+
+// SetOptions sets the value of Options.
+func (s *Page) SetOptions(val OptPageOptions) {
+	s.Options = val
+}
+
+// SetMeta sets the value of Meta.
+func (s *Page) SetMeta(val PageMeta) {
+	s.Meta = val
+}
+
+func (*Page) addPageRes() {}
+
+// Ref: #/components/schemas/pageMeta
+type PageMeta struct {
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Error       OptString `json:"error"`
+	// Media type of the page, other than HTML pages are saved as is in `raw` format.
+	ContentType OptString `json:"content_type"`
+	// Canonical URL of the page.
+	Canonical OptString `json:"canonical"`
+	Author    OptString `json:"author"`
+	SiteName  OptString `json:"site_name"`
+	// Page language from the `html` element `lang` attribute.
+	Language OptString `json:"language"`
+	Favicon  OptString `json:"favicon"`
+	// Preview image URL.
+	Image     OptString   `json:"image"`
+	Published OptDateTime `json:"published"`
+	Modified  OptDateTime `json:"modified"`
+	// OpenGraph properties without `og:` prefix.
+	Opengraph OptPageMetaOpengraph `json:"opengraph"`
+	// Twitter card properties without `twitter:` prefix.
+	Twitter OptPageMetaTwitter `json:"twitter"`
+}
+
+// GetTitle returns the value of Title.
+func (s *PageMeta) GetTitle() string {
+	return s.Title
+}
+
+// GetDescription returns the value of Description.
+func (s *PageMeta) GetDescription() string {
+	return s.Description
+}
+
+// GetError returns the value of Error.
+func (s *PageMeta) GetError() OptString {
+	return s.Error
+}
+
+// GetContentType returns the value of ContentType.
+func (s *PageMeta) GetContentType() OptString {
+	return s.ContentType
+}
+
+// GetCanonical returns the value of Canonical.
+func (s *PageMeta) GetCanonical() OptString {
+	return s.Canonical
+}
+
+// GetAuthor returns the value of Author.
+func (s *PageMeta) GetAuthor() OptString {
+	return s.Author
+}
+
+// GetSiteName returns the value of SiteName.
+func (s *PageMeta) GetSiteName() OptString {
+	return s.SiteName
+}
+
+// GetLanguage returns the value of Language.
+func (s *PageMeta) GetLanguage() OptString {
+	return s.Language
+}
+
+// GetFavicon returns the value of Favicon.
+func (s *PageMeta) GetFavicon() OptString {
+	return s.Favicon
+}
+
+// GetImage returns the value of Image.
+func (s *PageMeta) GetImage() OptString {
+	return s.Image
+}
+
+// GetPublished returns the value of Published.
+func (s *PageMeta) GetPublished() OptDateTime {
+	return s.Published
+}
+
+// GetModified returns the value of Modified.
+func (s *PageMeta) GetModified() OptDateTime {
+	return s.Modified
+}
+
+// GetOpengraph returns the value of Opengraph.
+func (s *PageMeta) GetOpengraph() OptPageMetaOpengraph {
+	return s.Opengraph
+}
+
+// GetTwitter returns the value of Twitter.
+func (s *PageMeta) GetTwitter() OptPageMetaTwitter {
+	return s.Twitter
+}
+
+// SetTitle sets the value of Title.
+func (s *PageMeta) SetTitle(val string) {
+	s.Title = val
+}
+
+// SetDescription sets the value of Description.
+func (s *PageMeta) SetDescription(val string) {
+	s.Description = val
+}
+
+// SetError sets the value of Error.
+func (s *PageMeta) SetError(val OptString) {
+	s.Error = val
+}
+
+// SetContentType sets the value of ContentType.
+func (s *PageMeta) SetContentType(val OptString) {
+	s.ContentType = val
+}
+
+// SetCanonical sets the value of Canonical.
+func (s *PageMeta) SetCanonical(val OptString) {
+	s.Canonical = val
+}
+
+// SetAuthor sets the value of Author.
+func (s *PageMeta) SetAuthor(val OptString) {
+	s.Author = val
+}
+
+// SetSiteName sets the value of SiteName.
+func (s *PageMeta) SetSiteName(val OptString) {
+	s.SiteName = val
+}
+
+// SetLanguage sets the value of Language.
+func (s *PageMeta) SetLanguage(val OptString) {
+	s.Language = val
+}
+
+// SetFavicon sets the value of Favicon.
+func (s *PageMeta) SetFavicon(val OptString) {
+	s.Favicon = val
+}
+
+// SetImage sets the value of Image.
+func (s *PageMeta) SetImage(val OptString) {
+	s.Image = val
+}
+
+// SetPublished sets the value of Published.
+func (s *PageMeta) SetPublished(val OptDateTime) {
+	s.Published = val
+}
+
+// SetModified sets the value of Modified.
+func (s *PageMeta) SetModified(val OptDateTime) {
+	s.Modified = val
 }
 
-func (*Page) addPageRes() {}
+// SetOpengraph sets the value of Opengraph.
+func (s *PageMeta) SetOpengraph(val OptPageMetaOpengraph) {
+	s.Opengraph = val
+}
 
-type PageMeta struct {
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Error       OptString `json:"error"`
+// SetTwitter sets the value of Twitter.
+func (s *PageMeta) SetTwitter(val OptPageMetaTwitter) {
+	s.Twitter = val
 }
 
-// GetTitle returns the value of Title.
-func (s *PageMeta) GetTitle() string {
-	return s.Title
+// OpenGraph properties without `og:` prefix.
+type PageMetaOpengraph map[string]string
+
+func (s *PageMetaOpengraph) init() PageMetaOpengraph {
+	m := *s
+	if m == nil {
+		m = map[string]string{}
+		*s = m
+	}
+	return m
 }
 
-// GetDescription returns the value of Description.
-func (s *PageMeta) GetDescription() string {
-	return s.Description
+// Twitter card properties without `twitter:` prefix.
+type PageMetaTwitter map[string]string
+
+func (s *PageMetaTwitter) init() PageMetaTwitter {
+	m := *s
+	if m == nil {
+		m = map[string]string{}
+		*s = m
+	}
+	return m
 }
 
-// GetError returns the value of Error.
-func (s *PageMeta) GetError() OptString {
-	return s.Error
+// Page capture options, overriding the service config.
+// Ref: #/components/schemas/pageOptions
+type PageOptions struct {
+	Pdf        OptPdfOptions        `json:"pdf"`
+	SingleFile OptSingleFileOptions `json:"single_file"`
 }
 
-// SetTitle sets the value of Title.
-func (s *PageMeta) SetTitle(val string) {
-	s.Title = val
+// GetPdf returns the value of Pdf.
+func (s *PageOptions) GetPdf() OptPdfOptions {
+	return s.Pdf
 }
 
-// SetDescription sets the value of Description.
-func (s *PageMeta) SetDescription(val string) {
-	s.Description = val
+// GetSingleFile returns the value of SingleFile.
+func (s *PageOptions) GetSingleFile() OptSingleFileOptions {
+	return s.SingleFile
 }
 
-// SetError sets the value of Error.
-func (s *PageMeta) SetError(val OptString) {
-	s.Error = val
+// SetPdf sets the value of Pdf.
+func (s *PageOptions) SetPdf(val OptPdfOptions) {
+	s.Pdf = val
+}
+
+// SetSingleFile sets the value of SingleFile.
+func (s *PageOptions) SetSingleFile(val OptSingleFileOptions) {
+	s.SingleFile = val
 }
 
 // Merged schema.
 // Ref: #/components/schemas/pageWithResults
 type PageWithResults struct {
-	ID      uuid.UUID           `json:"id"`
-	URL     string              `json:"url"`
-	Created time.Time           `json:"created"`
-	Formats []Format            `json:"formats"`
-	Status  Status              `json:"status"`
-	Meta    PageWithResultsMeta `json:"meta"`
-	Results []Result            `json:"results"`
+	ID      uuid.UUID `json:"id"`
+	URL     string    `json:"url"`
+	Created time.Time `json:"created"`
+	Formats []Format  `json:"formats"`
+	Status  Status    `json:"status"`
+	// ID of the page preview image file.
+	Thumbnail OptUUID        `json:"thumbnail"`
+	Options   OptPageOptions `json:"options"`
+	Meta      PageMeta       `json:"meta"`
+	Results   []Result       `json:"results"`
 }
 
 // GetID returns the value of ID.
@@ -481,8 +1437,18 @@ func (s *PageWithResults) GetStatus() Status {
 	return s.Status
 }
 
+// GetThumbnail returns the value of Thumbnail.
+func (s *PageWithResults) GetThumbnail() OptUUID {
+	return s.Thumbnail
+}
+
+// GetOptions returns the value of Options.
+func (s *PageWithResults) GetOptions() OptPageOptions {
+	return s.Options
+}
+
 // GetMeta returns the value of Meta.
-func (s *PageWithResults) GetMeta() PageWithResultsMeta {
+func (s *PageWithResults) GetMeta() PageMeta {
 	return s.Meta
 }
 
@@ -516,8 +1482,18 @@ func (s *PageWithResults) SetStatus(val Status) {
 	s.Status = val
 }
 
+// SetThumbnail sets the value of Thumbnail.
+func (s *PageWithResults) SetThumbnail(val OptUUID) {
+	s.Thumbnail = val
+}
+
+// SetOptions sets the value of Options.
+func (s *PageWithResults) SetOptions(val OptPageOptions) {
+	s.Options = val
+}
+
 // SetMeta sets the value of Meta.
-func (s *PageWithResults) SetMeta(val PageWithResultsMeta) {
+func (s *PageWithResults) SetMeta(val PageMeta) {
 	s.Meta = val
 }
 
@@ -528,49 +1504,494 @@ func (s *PageWithResults) SetResults(val []Result) {
 
 func (*PageWithResults) getPageRes() {}
 
-type PageWithResultsMeta struct {
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Error       OptString `json:"error"`
+type Pages []Page
+
+// Ref: #/components/schemas/pdfOptions
+type PdfOptions struct {
+	Landscape OptBool                `json:"landscape"`
+	Grayscale OptBool                `json:"grayscale"`
+	Zoom      OptFloat64             `json:"zoom"`
+	Viewport  OptString              `json:"viewport"`
+	PageSize  OptPdfOptionsPageSize  `json:"page_size"`
+	MediaType OptPdfOptionsMediaType `json:"media_type"`
 }
 
-// GetTitle returns the value of Title.
-func (s *PageWithResultsMeta) GetTitle() string {
-	return s.Title
+// GetLandscape returns the value of Landscape.
+func (s *PdfOptions) GetLandscape() OptBool {
+	return s.Landscape
 }
 
-// GetDescription returns the value of Description.
-func (s *PageWithResultsMeta) GetDescription() string {
-	return s.Description
+// GetGrayscale returns the value of Grayscale.
+func (s *PdfOptions) GetGrayscale() OptBool {
+	return s.Grayscale
 }
 
-// GetError returns the value of Error.
-func (s *PageWithResultsMeta) GetError() OptString {
-	return s.Error
+// GetZoom returns the value of Zoom.
+func (s *PdfOptions) GetZoom() OptFloat64 {
+	return s.Zoom
 }
 
-// SetTitle sets the value of Title.
-func (s *PageWithResultsMeta) SetTitle(val string) {
-	s.Title = val
+// GetViewport returns the value of Viewport.
+func (s *PdfOptions) GetViewport() OptString {
+	return s.Viewport
 }
 
-// SetDescription sets the value of Description.
-func (s *PageWithResultsMeta) SetDescription(val string) {
-	s.Description = val
+// GetPageSize returns the value of PageSize.
+func (s *PdfOptions) GetPageSize() OptPdfOptionsPageSize {
+	return s.PageSize
 }
 
-// SetError sets the value of Error.
-func (s *PageWithResultsMeta) SetError(val OptString) {
-	s.Error = val
+// GetMediaType returns the value of MediaType.
+func (s *PdfOptions) GetMediaType() OptPdfOptionsMediaType {
+	return s.MediaType
 }
 
-type Pages []Page
+// SetLandscape sets the value of Landscape.
+func (s *PdfOptions) SetLandscape(val OptBool) {
+	s.Landscape = val
+}
+
+// SetGrayscale sets the value of Grayscale.
+func (s *PdfOptions) SetGrayscale(val OptBool) {
+	s.Grayscale = val
+}
+
+// SetZoom sets the value of Zoom.
+func (s *PdfOptions) SetZoom(val OptFloat64) {
+	s.Zoom = val
+}
+
+// SetViewport sets the value of Viewport.
+func (s *PdfOptions) SetViewport(val OptString) {
+	s.Viewport = val
+}
+
+// SetPageSize sets the value of PageSize.
+func (s *PdfOptions) SetPageSize(val OptPdfOptionsPageSize) {
+	s.PageSize = val
+}
+
+// SetMediaType sets the value of MediaType.
+func (s *PdfOptions) SetMediaType(val OptPdfOptionsMediaType) {
+	s.MediaType = val
+}
+
+type PdfOptionsMediaType string
+
+const (
+	PdfOptionsMediaTypePrint  PdfOptionsMediaType = "print"
+	PdfOptionsMediaTypeScreen PdfOptionsMediaType = "screen"
+)
+
+// AllValues returns all PdfOptionsMediaType values.
+func (PdfOptionsMediaType) AllValues() []PdfOptionsMediaType {
+	return []PdfOptionsMediaType{
+		PdfOptionsMediaTypePrint,
+		PdfOptionsMediaTypeScreen,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s PdfOptionsMediaType) MarshalText() ([]byte, error) {
+	switch s {
+	case PdfOptionsMediaTypePrint:
+		return []byte(s), nil
+	case PdfOptionsMediaTypeScreen:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *PdfOptionsMediaType) UnmarshalText(data []byte) error {
+	switch PdfOptionsMediaType(data) {
+	case PdfOptionsMediaTypePrint:
+		*s = PdfOptionsMediaTypePrint
+		return nil
+	case PdfOptionsMediaTypeScreen:
+		*s = PdfOptionsMediaTypeScreen
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+type PdfOptionsPageSize string
+
+const (
+	PdfOptionsPageSizeA0        PdfOptionsPageSize = "A0"
+	PdfOptionsPageSizeA1        PdfOptionsPageSize = "A1"
+	PdfOptionsPageSizeA2        PdfOptionsPageSize = "A2"
+	PdfOptionsPageSizeA3        PdfOptionsPageSize = "A3"
+	PdfOptionsPageSizeA4        PdfOptionsPageSize = "A4"
+	PdfOptionsPageSizeA5        PdfOptionsPageSize = "A5"
+	PdfOptionsPageSizeA6        PdfOptionsPageSize = "A6"
+	PdfOptionsPageSizeA7        PdfOptionsPageSize = "A7"
+	PdfOptionsPageSizeA8        PdfOptionsPageSize = "A8"
+	PdfOptionsPageSizeA9        PdfOptionsPageSize = "A9"
+	PdfOptionsPageSizeB0        PdfOptionsPageSize = "B0"
+	PdfOptionsPageSizeB1        PdfOptionsPageSize = "B1"
+	PdfOptionsPageSizeB2        PdfOptionsPageSize = "B2"
+	PdfOptionsPageSizeB3        PdfOptionsPageSize = "B3"
+	PdfOptionsPageSizeB4        PdfOptionsPageSize = "B4"
+	PdfOptionsPageSizeB5        PdfOptionsPageSize = "B5"
+	PdfOptionsPageSizeB6        PdfOptionsPageSize = "B6"
+	PdfOptionsPageSizeB7        PdfOptionsPageSize = "B7"
+	PdfOptionsPageSizeB8        PdfOptionsPageSize = "B8"
+	PdfOptionsPageSizeB9        PdfOptionsPageSize = "B9"
+	PdfOptionsPageSizeB10       PdfOptionsPageSize = "B10"
+	PdfOptionsPageSizeC5E       PdfOptionsPageSize = "C5E"
+	PdfOptionsPageSizeComm10E   PdfOptionsPageSize = "Comm10E"
+	PdfOptionsPageSizeDLE       PdfOptionsPageSize = "DLE"
+	PdfOptionsPageSizeExecutive PdfOptionsPageSize = "Executive"
+	PdfOptionsPageSizeFolio     PdfOptionsPageSize = "Folio"
+	PdfOptionsPageSizeLedger    PdfOptionsPageSize = "Ledger"
+	PdfOptionsPageSizeLegal     PdfOptionsPageSize = "Legal"
+	PdfOptionsPageSizeLetter    PdfOptionsPageSize = "Letter"
+	PdfOptionsPageSizeTabloid   PdfOptionsPageSize = "Tabloid"
+)
+
+// AllValues returns all PdfOptionsPageSize values.
+func (PdfOptionsPageSize) AllValues() []PdfOptionsPageSize {
+	return []PdfOptionsPageSize{
+		PdfOptionsPageSizeA0,
+		PdfOptionsPageSizeA1,
+		PdfOptionsPageSizeA2,
+		PdfOptionsPageSizeA3,
+		PdfOptionsPageSizeA4,
+		PdfOptionsPageSizeA5,
+		PdfOptionsPageSizeA6,
+		PdfOptionsPageSizeA7,
+		PdfOptionsPageSizeA8,
+		PdfOptionsPageSizeA9,
+		PdfOptionsPageSizeB0,
+		PdfOptionsPageSizeB1,
+		PdfOptionsPageSizeB2,
+		PdfOptionsPageSizeB3,
+		PdfOptionsPageSizeB4,
+		PdfOptionsPageSizeB5,
+		PdfOptionsPageSizeB6,
+		PdfOptionsPageSizeB7,
+		PdfOptionsPageSizeB8,
+		PdfOptionsPageSizeB9,
+		PdfOptionsPageSizeB10,
+		PdfOptionsPageSizeC5E,
+		PdfOptionsPageSizeComm10E,
+		PdfOptionsPageSizeDLE,
+		PdfOptionsPageSizeExecutive,
+		PdfOptionsPageSizeFolio,
+		PdfOptionsPageSizeLedger,
+		PdfOptionsPageSizeLegal,
+		PdfOptionsPageSizeLetter,
+		PdfOptionsPageSizeTabloid,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s PdfOptionsPageSize) MarshalText() ([]byte, error) {
+	switch s {
+	case PdfOptionsPageSizeA0:
+		return []byte(s), nil
+	case PdfOptionsPageSizeA1:
+		return []byte(s), nil
+	case PdfOptionsPageSizeA2:
+		return []byte(s), nil
+	case PdfOptionsPageSizeA3:
+		return []byte(s), nil
+	case PdfOptionsPageSizeA4:
+		return []byte(s), nil
+	case PdfOptionsPageSizeA5:
+		return []byte(s), nil
+	case PdfOptionsPageSizeA6:
+		return []byte(s), nil
+	case PdfOptionsPageSizeA7:
+		return []byte(s), nil
+	case PdfOptionsPageSizeA8:
+		return []byte(s), nil
+	case PdfOptionsPageSizeA9:
+		return []byte(s), nil
+	case PdfOptionsPageSizeB0:
+		return []byte(s), nil
+	case PdfOptionsPageSizeB1:
+		return []byte(s), nil
+	case PdfOptionsPageSizeB2:
+		return []byte(s), nil
+	case PdfOptionsPageSizeB3:
+		return []byte(s), nil
+	case PdfOptionsPageSizeB4:
+		return []byte(s), nil
+	case PdfOptionsPageSizeB5:
+		return []byte(s), nil
+	case PdfOptionsPageSizeB6:
+		return []byte(s), nil
+	case PdfOptionsPageSizeB7:
+		return []byte(s), nil
+	case PdfOptionsPageSizeB8:
+		return []byte(s), nil
+	case PdfOptionsPageSizeB9:
+		return []byte(s), nil
+	case PdfOptionsPageSizeB10:
+		return []byte(s), nil
+	case PdfOptionsPageSizeC5E:
+		return []byte(s), nil
+	case PdfOptionsPageSizeComm10E:
+		return []byte(s), nil
+	case PdfOptionsPageSizeDLE:
+		return []byte(s), nil
+	case PdfOptionsPageSizeExecutive:
+		return []byte(s), nil
+	case PdfOptionsPageSizeFolio:
+		return []byte(s), nil
+	case PdfOptionsPageSizeLedger:
+		return []byte(s), nil
+	case PdfOptionsPageSizeLegal:
+		return []byte(s), nil
+	case PdfOptionsPageSizeLetter:
+		return []byte(s), nil
+	case PdfOptionsPageSizeTabloid:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *PdfOptionsPageSize) UnmarshalText(data []byte) error {
+	switch PdfOptionsPageSize(data) {
+	case PdfOptionsPageSizeA0:
+		*s = PdfOptionsPageSizeA0
+		return nil
+	case PdfOptionsPageSizeA1:
+		*s = PdfOptionsPageSizeA1
+		return nil
+	case PdfOptionsPageSizeA2:
+		*s = PdfOptionsPageSizeA2
+		return nil
+	case PdfOptionsPageSizeA3:
+		*s = PdfOptionsPageSizeA3
+		return nil
+	case PdfOptionsPageSizeA4:
+		*s = PdfOptionsPageSizeA4
+		return nil
+	case PdfOptionsPageSizeA5:
+		*s = PdfOptionsPageSizeA5
+		return nil
+	case PdfOptionsPageSizeA6:
+		*s = PdfOptionsPageSizeA6
+		return nil
+	case PdfOptionsPageSizeA7:
+		*s = PdfOptionsPageSizeA7
+		return nil
+	case PdfOptionsPageSizeA8:
+		*s = PdfOptionsPageSizeA8
+		return nil
+	case PdfOptionsPageSizeA9:
+		*s = PdfOptionsPageSizeA9
+		return nil
+	case PdfOptionsPageSizeB0:
+		*s = PdfOptionsPageSizeB0
+		return nil
+	case PdfOptionsPageSizeB1:
+		*s = PdfOptionsPageSizeB1
+		return nil
+	case PdfOptionsPageSizeB2:
+		*s = PdfOptionsPageSizeB2
+		return nil
+	case PdfOptionsPageSizeB3:
+		*s = PdfOptionsPageSizeB3
+		return nil
+	case PdfOptionsPageSizeB4:
+		*s = PdfOptionsPageSizeB4
+		return nil
+	case PdfOptionsPageSizeB5:
+		*s = PdfOptionsPageSizeB5
+		return nil
+	case PdfOptionsPageSizeB6:
+		*s = PdfOptionsPageSizeB6
+		return nil
+	case PdfOptionsPageSizeB7:
+		*s = PdfOptionsPageSizeB7
+		return nil
+	case PdfOptionsPageSizeB8:
+		*s = PdfOptionsPageSizeB8
+		return nil
+	case PdfOptionsPageSizeB9:
+		*s = PdfOptionsPageSizeB9
+		return nil
+	case PdfOptionsPageSizeB10:
+		*s = PdfOptionsPageSizeB10
+		return nil
+	case PdfOptionsPageSizeC5E:
+		*s = PdfOptionsPageSizeC5E
+		return nil
+	case PdfOptionsPageSizeComm10E:
+		*s = PdfOptionsPageSizeComm10E
+		return nil
+	case PdfOptionsPageSizeDLE:
+		*s = PdfOptionsPageSizeDLE
+		return nil
+	case PdfOptionsPageSizeExecutive:
+		*s = PdfOptionsPageSizeExecutive
+		return nil
+	case PdfOptionsPageSizeFolio:
+		*s = PdfOptionsPageSizeFolio
+		return nil
+	case PdfOptionsPageSizeLedger:
+		*s = PdfOptionsPageSizeLedger
+		return nil
+	case PdfOptionsPageSizeLegal:
+		*s = PdfOptionsPageSizeLegal
+		return nil
+	case PdfOptionsPageSizeLetter:
+		*s = PdfOptionsPageSizeLetter
+		return nil
+	case PdfOptionsPageSizeTabloid:
+		*s = PdfOptionsPageSizeTabloid
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/profile
+type Profile struct {
+	ID       uuid.UUID `json:"id"`
+	Name     string    `json:"name"`
+	Domain   string    `json:"domain"`
+	Created  time.Time `json:"created"`
+	Username OptString `json:"username"`
+	// Names of the profile headers.
+	Headers []string             `json:"headers"`
+	Cookies []ProfileCookiesItem `json:"cookies"`
+}
+
+// GetID returns the value of ID.
+func (s *Profile) GetID() uuid.UUID {
+	return s.ID
+}
+
+// GetName returns the value of Name.
+func (s *Profile) GetName() string {
+	return s.Name
+}
+
+// GetDomain returns the value of Domain.
+func (s *Profile) GetDomain() string {
+	return s.Domain
+}
+
+// GetCreated returns the value of Created.
+func (s *Profile) GetCreated() time.Time {
+	return s.Created
+}
+
+// GetUsername returns the value of Username.
+func (s *Profile) GetUsername() OptString {
+	return s.Username
+}
+
+// GetHeaders returns the value of Headers.
+func (s *Profile) GetHeaders() []string {
+	return s.Headers
+}
+
+// GetCookies returns the value of Cookies.
+func (s *Profile) GetCookies() []ProfileCookiesItem {
+	return s.Cookies
+}
+
+// SetID sets the value of ID.
+func (s *Profile) SetID(val uuid.UUID) {
+	s.ID = val
+}
+
+// SetName sets the value of Name.
+func (s *Profile) SetName(val string) {
+	s.Name = val
+}
+
+// SetDomain sets the value of Domain.
+func (s *Profile) SetDomain(val string) {
+	s.Domain = val
+}
+
+// SetCreated sets the value of Created.
+func (s *Profile) SetCreated(val time.Time) {
+	s.Created = val
+}
+
+// SetUsername sets the value of Username.
+func (s *Profile) SetUsername(val OptString) {
+	s.Username = val
+}
+
+// SetHeaders sets the value of Headers.
+func (s *Profile) SetHeaders(val []string) {
+	s.Headers = val
+}
+
+// SetCookies sets the value of Cookies.
+func (s *Profile) SetCookies(val []ProfileCookiesItem) {
+	s.Cookies = val
+}
+
+func (*Profile) addProfileRes() {}
+
+type ProfileCookiesItem struct {
+	Name    string      `json:"name"`
+	Domain  string      `json:"domain"`
+	Path    string      `json:"path"`
+	Expires OptDateTime `json:"expires"`
+}
+
+// GetName returns the value of Name.
+func (s *ProfileCookiesItem) GetName() string {
+	return s.Name
+}
+
+// GetDomain returns the value of Domain.
+func (s *ProfileCookiesItem) GetDomain() string {
+	return s.Domain
+}
+
+// GetPath returns the value of Path.
+func (s *ProfileCookiesItem) GetPath() string {
+	return s.Path
+}
+
+// GetExpires returns the value of Expires.
+func (s *ProfileCookiesItem) GetExpires() OptDateTime {
+	return s.Expires
+}
+
+// SetName sets the value of Name.
+func (s *ProfileCookiesItem) SetName(val string) {
+	s.Name = val
+}
+
+// SetDomain sets the value of Domain.
+func (s *ProfileCookiesItem) SetDomain(val string) {
+	s.Domain = val
+}
+
+// SetPath sets the value of Path.
+func (s *ProfileCookiesItem) SetPath(val string) {
+	s.Path = val
+}
+
+// SetExpires sets the value of Expires.
+func (s *ProfileCookiesItem) SetExpires(val OptDateTime) {
+	s.Expires = val
+}
 
 // Ref: #/components/schemas/result
 type Result struct {
 	Format Format            `json:"format"`
 	Error  OptString         `json:"error"`
 	Files  []ResultFilesItem `json:"files"`
+	// Processing attempts, formats failed with transient errors are retried.
+	Attempts []Attempt `json:"attempts"`
 }
 
 // GetFormat returns the value of Format.
@@ -588,6 +2009,11 @@ func (s *Result) GetFiles() []ResultFilesItem {
 	return s.Files
 }
 
+// GetAttempts returns the value of Attempts.
+func (s *Result) GetAttempts() []Attempt {
+	return s.Attempts
+}
+
 // SetFormat sets the value of Format.
 func (s *Result) SetFormat(val Format) {
 	s.Format = val
@@ -603,6 +2029,11 @@ func (s *Result) SetFiles(val []ResultFilesItem) {
 	s.Files = val
 }
 
+// SetAttempts sets the value of Attempts.
+func (s *Result) SetAttempts(val []Attempt) {
+	s.Attempts = val
+}
+
 type ResultFilesItem struct {
 	ID       uuid.UUID `json:"id"`
 	Name     string    `json:"name"`
@@ -650,6 +2081,22 @@ func (s *ResultFilesItem) SetSize(val int64) {
 	s.Size = val
 }
 
+// Ref: #/components/schemas/singleFileOptions
+type SingleFileOptions struct {
+	// Remove scripts, event handlers, tracking pixels, ads and trackers.
+	Static OptBool `json:"static"`
+}
+
+// GetStatic returns the value of Static.
+func (s *SingleFileOptions) GetStatic() OptBool {
+	return s.Static
+}
+
+// SetStatic sets the value of Static.
+func (s *SingleFileOptions) SetStatic(val OptBool) {
+	s.Static = val
+}
+
 // Ref: #/components/schemas/status
 type Status string
 
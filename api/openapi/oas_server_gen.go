@@ -14,6 +14,18 @@ type Handler interface {
 	//
 	// POST /pages
 	AddPage(ctx context.Context, req OptAddPageReq, params AddPageParams) (AddPageRes, error)
+	// AddProfile implements addProfile operation.
+	//
+	// Add credential profile.
+	//
+	// POST /profiles
+	AddProfile(ctx context.Context, req *AddProfileReq) (AddProfileRes, error)
+	// DeleteProfile implements deleteProfile operation.
+	//
+	// Delete credential profile.
+	//
+	// DELETE /profiles/{id}
+	DeleteProfile(ctx context.Context, params DeleteProfileParams) (DeleteProfileRes, error)
 	// GetFile implements getFile operation.
 	//
 	// Get file content.
@@ -32,6 +44,12 @@ type Handler interface {
 	//
 	// GET /pages
 	GetPages(ctx context.Context) (Pages, error)
+	// GetProfiles implements getProfiles operation.
+	//
+	// Get all credential profiles.
+	//
+	// GET /profiles
+	GetProfiles(ctx context.Context) ([]Profile, error)
 	// NewError creates *ErrorStatusCode from error returned by handler.
 	//
 	// Used for common default response.
@@ -22,6 +22,24 @@ func (UnimplementedHandler) AddPage(ctx context.Context, req OptAddPageReq, para
 	return r, ht.ErrNotImplemented
 }
 
+// AddProfile implements addProfile operation.
+//
+// Add credential profile.
+//
+// POST /profiles
+func (UnimplementedHandler) AddProfile(ctx context.Context, req *AddProfileReq) (r AddProfileRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// DeleteProfile implements deleteProfile operation.
+//
+// Delete credential profile.
+//
+// DELETE /profiles/{id}
+func (UnimplementedHandler) DeleteProfile(ctx context.Context, params DeleteProfileParams) (r DeleteProfileRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // GetFile implements getFile operation.
 //
 // Get file content.
@@ -49,6 +67,15 @@ func (UnimplementedHandler) GetPages(ctx context.Context) (r Pages, _ error) {
 	return r, ht.ErrNotImplemented
 }
 
+// GetProfiles implements getProfiles operation.
+//
+// Get all credential profiles.
+//
+// GET /profiles
+func (UnimplementedHandler) GetProfiles(ctx context.Context) (r []Profile, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // NewError creates *ErrorStatusCode from error returned by handler.
 //
 // Used for common default response.
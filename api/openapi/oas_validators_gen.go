@@ -13,27 +13,20 @@ import (
 func (s *AddPageReq) Validate() error {
 	var failures []validate.FieldError
 	if err := func() error {
-		var failures []validate.FieldError
-		for i, elem := range s.Formats {
+		if value, ok := s.Options.Get(); ok {
 			if err := func() error {
-				if err := elem.Validate(); err != nil {
+				if err := value.Validate(); err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				failures = append(failures, validate.FieldError{
-					Name:  fmt.Sprintf("[%d]", i),
-					Error: err,
-				})
+				return err
 			}
 		}
-		if len(failures) > 0 {
-			return &validate.Error{Fields: failures}
-		}
 		return nil
 	}(); err != nil {
 		failures = append(failures, validate.FieldError{
-			Name:  "formats",
+			Name:  "options",
 			Error: err,
 		})
 	}
@@ -43,44 +36,12 @@ func (s *AddPageReq) Validate() error {
 	return nil
 }
 
-func (s Format) Validate() error {
-	switch s {
-	case "all":
-		return nil
-	case "pdf":
-		return nil
-	case "single_file":
-		return nil
-	case "headers":
-		return nil
-	default:
-		return errors.Errorf("invalid value: %v", s)
-	}
-}
-
 func (s *Page) Validate() error {
 	var failures []validate.FieldError
 	if err := func() error {
 		if s.Formats == nil {
 			return errors.New("nil is invalid value")
 		}
-		var failures []validate.FieldError
-		for i, elem := range s.Formats {
-			if err := func() error {
-				if err := elem.Validate(); err != nil {
-					return err
-				}
-				return nil
-			}(); err != nil {
-				failures = append(failures, validate.FieldError{
-					Name:  fmt.Sprintf("[%d]", i),
-					Error: err,
-				})
-			}
-		}
-		if len(failures) > 0 {
-			return &validate.Error{Fields: failures}
-		}
 		return nil
 	}(); err != nil {
 		failures = append(failures, validate.FieldError{
@@ -99,34 +60,61 @@ func (s *Page) Validate() error {
 			Error: err,
 		})
 	}
+	if err := func() error {
+		if value, ok := s.Options.Get(); ok {
+			if err := func() error {
+				if err := value.Validate(); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		failures = append(failures, validate.FieldError{
+			Name:  "options",
+			Error: err,
+		})
+	}
 	if len(failures) > 0 {
 		return &validate.Error{Fields: failures}
 	}
 	return nil
 }
 
-func (s *PageWithResults) Validate() error {
+func (s *PageOptions) Validate() error {
 	var failures []validate.FieldError
 	if err := func() error {
-		if s.Formats == nil {
-			return errors.New("nil is invalid value")
-		}
-		var failures []validate.FieldError
-		for i, elem := range s.Formats {
+		if value, ok := s.Pdf.Get(); ok {
 			if err := func() error {
-				if err := elem.Validate(); err != nil {
+				if err := value.Validate(); err != nil {
 					return err
 				}
 				return nil
 			}(); err != nil {
-				failures = append(failures, validate.FieldError{
-					Name:  fmt.Sprintf("[%d]", i),
-					Error: err,
-				})
+				return err
 			}
 		}
-		if len(failures) > 0 {
-			return &validate.Error{Fields: failures}
+		return nil
+	}(); err != nil {
+		failures = append(failures, validate.FieldError{
+			Name:  "pdf",
+			Error: err,
+		})
+	}
+	if len(failures) > 0 {
+		return &validate.Error{Fields: failures}
+	}
+	return nil
+}
+
+func (s *PageWithResults) Validate() error {
+	var failures []validate.FieldError
+	if err := func() error {
+		if s.Formats == nil {
+			return errors.New("nil is invalid value")
 		}
 		return nil
 	}(); err != nil {
@@ -146,6 +134,24 @@ func (s *PageWithResults) Validate() error {
 			Error: err,
 		})
 	}
+	if err := func() error {
+		if value, ok := s.Options.Get(); ok {
+			if err := func() error {
+				if err := value.Validate(); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		failures = append(failures, validate.FieldError{
+			Name:  "options",
+			Error: err,
+		})
+	}
 	if err := func() error {
 		if s.Results == nil {
 			return errors.New("nil is invalid value")
@@ -205,19 +211,213 @@ func (s Pages) Validate() error {
 	return nil
 }
 
-func (s *Result) Validate() error {
+func (s *PdfOptions) Validate() error {
 	var failures []validate.FieldError
 	if err := func() error {
-		if err := s.Format.Validate(); err != nil {
-			return err
+		if value, ok := s.Zoom.Get(); ok {
+			if err := func() error {
+				if err := (validate.Float{
+					MinSet:        true,
+					Min:           0,
+					MaxSet:        true,
+					Max:           10,
+					MinExclusive:  true,
+					MaxExclusive:  false,
+					MultipleOfSet: false,
+					MultipleOf:    nil,
+				}).Validate(float64(value)); err != nil {
+					return errors.Wrap(err, "float")
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
 		}
 		return nil
 	}(); err != nil {
 		failures = append(failures, validate.FieldError{
-			Name:  "format",
+			Name:  "zoom",
 			Error: err,
 		})
 	}
+	if err := func() error {
+		if value, ok := s.Viewport.Get(); ok {
+			if err := func() error {
+				if err := (validate.String{
+					MinLength:    0,
+					MinLengthSet: false,
+					MaxLength:    0,
+					MaxLengthSet: false,
+					Email:        false,
+					Hostname:     false,
+					Regex:        regexMap["^[0-9]+x[0-9]+$"],
+				}).Validate(string(value)); err != nil {
+					return errors.Wrap(err, "string")
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		failures = append(failures, validate.FieldError{
+			Name:  "viewport",
+			Error: err,
+		})
+	}
+	if err := func() error {
+		if value, ok := s.PageSize.Get(); ok {
+			if err := func() error {
+				if err := value.Validate(); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		failures = append(failures, validate.FieldError{
+			Name:  "page_size",
+			Error: err,
+		})
+	}
+	if err := func() error {
+		if value, ok := s.MediaType.Get(); ok {
+			if err := func() error {
+				if err := value.Validate(); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		failures = append(failures, validate.FieldError{
+			Name:  "media_type",
+			Error: err,
+		})
+	}
+	if len(failures) > 0 {
+		return &validate.Error{Fields: failures}
+	}
+	return nil
+}
+
+func (s PdfOptionsMediaType) Validate() error {
+	switch s {
+	case "print":
+		return nil
+	case "screen":
+		return nil
+	default:
+		return errors.Errorf("invalid value: %v", s)
+	}
+}
+
+func (s PdfOptionsPageSize) Validate() error {
+	switch s {
+	case "A0":
+		return nil
+	case "A1":
+		return nil
+	case "A2":
+		return nil
+	case "A3":
+		return nil
+	case "A4":
+		return nil
+	case "A5":
+		return nil
+	case "A6":
+		return nil
+	case "A7":
+		return nil
+	case "A8":
+		return nil
+	case "A9":
+		return nil
+	case "B0":
+		return nil
+	case "B1":
+		return nil
+	case "B2":
+		return nil
+	case "B3":
+		return nil
+	case "B4":
+		return nil
+	case "B5":
+		return nil
+	case "B6":
+		return nil
+	case "B7":
+		return nil
+	case "B8":
+		return nil
+	case "B9":
+		return nil
+	case "B10":
+		return nil
+	case "C5E":
+		return nil
+	case "Comm10E":
+		return nil
+	case "DLE":
+		return nil
+	case "Executive":
+		return nil
+	case "Folio":
+		return nil
+	case "Ledger":
+		return nil
+	case "Legal":
+		return nil
+	case "Letter":
+		return nil
+	case "Tabloid":
+		return nil
+	default:
+		return errors.Errorf("invalid value: %v", s)
+	}
+}
+
+func (s *Profile) Validate() error {
+	var failures []validate.FieldError
+	if err := func() error {
+		if s.Headers == nil {
+			return errors.New("nil is invalid value")
+		}
+		return nil
+	}(); err != nil {
+		failures = append(failures, validate.FieldError{
+			Name:  "headers",
+			Error: err,
+		})
+	}
+	if err := func() error {
+		if s.Cookies == nil {
+			return errors.New("nil is invalid value")
+		}
+		return nil
+	}(); err != nil {
+		failures = append(failures, validate.FieldError{
+			Name:  "cookies",
+			Error: err,
+		})
+	}
+	if len(failures) > 0 {
+		return &validate.Error{Fields: failures}
+	}
+	return nil
+}
+
+func (s *Result) Validate() error {
+	var failures []validate.FieldError
 	if err := func() error {
 		if s.Files == nil {
 			return errors.New("nil is invalid value")
@@ -229,6 +429,17 @@ func (s *Result) Validate() error {
 			Error: err,
 		})
 	}
+	if err := func() error {
+		if s.Attempts == nil {
+			return errors.New("nil is invalid value")
+		}
+		return nil
+	}(); err != nil {
+		failures = append(failures, validate.FieldError{
+			Name:  "attempts",
+			Error: err,
+		})
+	}
 	if len(failures) > 0 {
 		return &validate.Error{Fields: failures}
 	}
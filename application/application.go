@@ -40,16 +40,28 @@ func NewApplication(cfg config.Config) (Application, error) {
 		return Application{}, fmt.Errorf("new page repo: %w", err)
 	}
 
+	profileRepo, err := badgerRepo.NewProfile(db)
+	if err != nil {
+		return Application{}, fmt.Errorf("new profile repo: %w", err)
+	}
+
 	processor, err := processors.NewProcessors(cfg, log.Named("processor"))
 	if err != nil {
 		return Application{}, fmt.Errorf("new processors: %w", err)
 	}
 
+	processor.WithProfiles(profileRepo)
+
 	workerCh := make(chan *entity.Page)
-	worker := entity.NewWorker(workerCh, pageRepo, processor, log.Named("worker"))
+	worker := entity.NewWorker(workerCh, pageRepo, processor, log.Named("worker")).
+		WithRetry(entity.RetryPolicy{
+			MaxAttempts:  cfg.Retry.MaxAttempts,
+			InitialDelay: cfg.Retry.InitialDelay,
+			MaxDelay:     cfg.Retry.MaxDelay,
+		})
 
 	server, err := openapi.NewServer(
-		rest.NewService(pageRepo, workerCh, processor),
+		rest.NewService(pageRepo, profileRepo, workerCh, processor),
 		openapi.WithPathPrefix("/api/v1"),
 		openapi.WithMiddleware(
 			func(r middleware.Request, next middleware.Next) (middleware.Response, error) {
@@ -2,7 +2,10 @@ package config
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/sethvargo/go-envconfig"
 )
@@ -28,11 +31,84 @@ func NewConfig(ctx context.Context) (Config, error) {
 }
 
 type Config struct {
-	DB      DB      `env:",prefix=DB_"`
-	Logging Logging `env:",prefix=LOGGING_"`
-	API     API     `env:",prefix=API_"`
-	UI      UI      `env:",prefix=UI_"`
-	PDF     PDF     `env:",prefix=PDF_"`
+	DB         DB         `env:",prefix=DB_"`
+	Logging    Logging    `env:",prefix=LOGGING_"`
+	API        API        `env:",prefix=API_"`
+	UI         UI         `env:",prefix=UI_"`
+	Client     Client     `env:",prefix=CLIENT_"`
+	Politeness Politeness `env:",prefix=POLITENESS_"`
+	Egress     Egress     `env:",prefix=EGRESS_"`
+	Retry      Retry      `env:",prefix=RETRY_"`
+	Headers    Headers    `env:",prefix=HEADERS_"`
+	PDF        PDF        `env:",prefix=PDF_"`
+	Inline     Inline     `env:",prefix=INLINE_"`
+	SingleFile SingleFile `env:",prefix=SINGLE_FILE_"`
+	Screenshot Screenshot `env:",prefix=SCREENSHOT_"`
+
+	ExternalFormats ExternalFormats `env:"EXTERNAL_FORMATS"`
+}
+
+// Client configures the HTTP client of all formats, wkhtmltopdf and wkhtmltoimage use its proxy and headers too.
+type Client struct {
+	UserAgent string `env:"USER_AGENT,default=Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Safari/537.36"`
+	// Proxy is http, https, socks5 or socks5h proxy URL, the standard proxy env variables are used if empty.
+	Proxy string `env:"PROXY"`
+	// Headers are added to all requests, in format Name:value;Other-Name:value.
+	Headers        map[string]string `env:"HEADERS,delimiter=;"`
+	AcceptLanguage string            `env:"ACCEPT_LANGUAGE"`
+	MaxRedirects   int               `env:"MAX_REDIRECTS,default=10"`
+	// Timeout limits the whole request including the body reading.
+	Timeout               time.Duration `env:"TIMEOUT,default=30s"`
+	DialTimeout           time.Duration `env:"DIAL_TIMEOUT,default=10s"`
+	ResponseHeaderTimeout time.Duration `env:"RESPONSE_HEADER_TIMEOUT,default=20s"`
+	// TLSCAFile is PEM file with the certificates trusted in addition to the system ones.
+	TLSCAFile   string `env:"TLS_CA_FILE"`
+	TLSInsecure bool   `env:"TLS_INSECURE,default=false"`
+}
+
+// Politeness limits the requests to every host. The waiting counts in the Client.Timeout.
+type Politeness struct {
+	RobotsTxt bool `env:"ROBOTS_TXT,default=false"`
+	// RobotsUserAgent is the product token used to find the robots.txt rules group.
+	RobotsUserAgent string `env:"ROBOTS_USER_AGENT,default=webarchive"`
+	// HostRate is the maximum number of requests per second to one host, 0 means no limit.
+	HostRate float64 `env:"HOST_RATE,default=0"`
+	// HostConcurrency is the maximum number of simultaneous requests to one host, 0 means no limit.
+	HostConcurrency int `env:"HOST_CONCURRENCY,default=4"`
+	// BackoffRetries is the number of retries of the requests answered with 429 or 503 status.
+	BackoffRetries int `env:"BACKOFF_RETRIES,default=3"`
+	// MaxBackoff limits the waiting before retry, the responses asking to wait longer are returned as is.
+	MaxBackoff time.Duration `env:"MAX_BACKOFF,default=30s"`
+}
+
+// Egress limits the addresses the pages and their resources are fetched from, so the service can't be used
+// to reach the internal network. Loopback, private, link-local and cloud metadata addresses are denied by default.
+// The hostnames are checked on every redirect, the addresses after DNS resolution.
+type Egress struct {
+	Enabled bool `env:"ENABLED,default=true"`
+	// AllowedNetworks are CIDRs or addresses allowed in spite of the default denied ones, like 10.1.0.0/16.
+	AllowedNetworks []string `env:"ALLOWED_NETWORKS"`
+	DeniedNetworks  []string `env:"DENIED_NETWORKS"`
+	// AllowedHosts are not checked by address. Host like example.com matches the host and its subdomains,
+	// *.example.com matches the subdomains only.
+	AllowedHosts []string `env:"ALLOWED_HOSTS"`
+	DeniedHosts  []string `env:"DENIED_HOSTS"`
+}
+
+// Retry configures the repeated processing of the formats failed with transient errors, like timeouts,
+// connection resets, 5xx and 429 responses.
+type Retry struct {
+	// MaxAttempts is the number of the format processing attempts, 1 disables retries.
+	MaxAttempts int `env:"MAX_ATTEMPTS,default=3"`
+	// InitialDelay is the delay before the first retry, it is doubled for every next one.
+	InitialDelay time.Duration `env:"INITIAL_DELAY,default=5s"`
+	MaxDelay     time.Duration `env:"MAX_DELAY,default=1m"`
+}
+
+type Headers struct {
+	// Method is used for the headers request, some servers answer HEAD differently from GET.
+	Method       string `env:"METHOD,default=HEAD"`
+	MaxRedirects int    `env:"MAX_REDIRECTS,default=10"`
 }
 
 type PDF struct {
@@ -41,10 +117,151 @@ type PDF struct {
 	MediaPrint bool    `env:"MEDIA_PRINT,default=true"`
 	Zoom       float64 `env:"ZOOM,default=1"`
 	Viewport   string  `env:"VIEWPORT,default=1280x720"`
+	PageSize   string  `env:"PAGE_SIZE,default=A4"`
 	DPI        uint    `env:"DPI,default=150"`
 	Filename   string  `env:"FILENAME,default=page.pdf"`
 }
 
+// Inline is used by the formats which download the page resources: single_file, html_bundle, warc and epub.
+type Inline struct {
+	Concurrency int `env:"CONCURRENCY,default=8"`
+	// FrameDepth limits the nested iframes inlining, 0 disables it. EPUB doesn't inline frames.
+	FrameDepth int    `env:"FRAME_DEPTH,default=2"`
+	Images     Images `env:",prefix=IMAGES_"`
+}
+
+const (
+	ImageFormatJPEG = "jpeg"
+	ImageFormatPNG  = "png"
+	// ImageFormatKeep encodes the resized image in its source format.
+	ImageFormatKeep = "keep"
+)
+
+// Images is the policy of the images embedded into single_file documents. Images larger than max dimensions
+// are resized and converted to the format, smaller ones are kept as is.
+type Images struct {
+	// Original keeps all images as is, other settings are ignored.
+	Original  bool        `env:"ORIGINAL,default=false"`
+	MaxWidth  int         `env:"MAX_WIDTH,default=1024"`
+	MaxHeight int         `env:"MAX_HEIGHT,default=1024"`
+	Format    ImageFormat `env:"FORMAT,default=jpeg"`
+	Quality   int         `env:"QUALITY,default=90"`
+	// KeepTransparency stores the resized images with transparency as PNG, even if the format is JPEG.
+	KeepTransparency bool `env:"KEEP_TRANSPARENCY,default=true"`
+	SkipAnimated     bool `env:"SKIP_ANIMATED,default=true"`
+}
+
+type ImageFormat string
+
+func (f *ImageFormat) EnvDecode(val string) error {
+	switch val {
+	case ImageFormatJPEG, ImageFormatPNG, ImageFormatKeep:
+		*f = ImageFormat(val)
+
+		return nil
+
+	default:
+		return fmt.Errorf("unknown image format %s, want one of %s, %s, %s",
+			val, ImageFormatJPEG, ImageFormatPNG, ImageFormatKeep)
+	}
+}
+
+type SingleFile struct {
+	// Static removes scripts, event handlers, tracking pixels and blocked elements, can be overridden for the page.
+	Static bool `env:"STATIC,default=false"`
+	// DefaultBlocklist enables the bundled ad and tracker hosts and selectors in addition to the configured ones.
+	DefaultBlocklist bool     `env:"DEFAULT_BLOCKLIST,default=true"`
+	BlockedHosts     []string `env:"BLOCKED_HOSTS"`
+	BlockedSelectors []string `env:"BLOCKED_SELECTORS,delimiter=;"`
+}
+
+type Screenshot struct {
+	Viewport       string `env:"VIEWPORT,default=1280x720"`
+	MaxHeight      int    `env:"MAX_HEIGHT,default=20000"`
+	Quality        int    `env:"QUALITY,default=90"`
+	ThumbnailWidth int    `env:"THUMBNAIL_WIDTH,default=400"`
+	Filename       string `env:"FILENAME,default=screenshot.png"`
+}
+
+const (
+	ExternalInputURL  = "url"
+	ExternalInputHTML = "html"
+)
+
+// ExternalFormatMaxID is the largest ExternalFormat.ID.
+const ExternalFormatMaxID = 127
+
+// ExternalFormat declares the format made by external command. Page URL substitutes
+// the {url} placeholder in the command arguments, command output is stored as the result file.
+type ExternalFormat struct {
+	// ID is the stable format number stored with the pages, from 1 to ExternalFormatMaxID.
+	ID      int      `json:"id"`
+	Name    string   `json:"name"`
+	Command []string `json:"command"`
+	// Input is ExternalInputHTML to pass page html to the command stdin, or ExternalInputURL to pass nothing.
+	Input    string `json:"input"`
+	Filename string `json:"filename"`
+	// MimeType overrides the detected output file mimetype, if set.
+	MimeType string `json:"mimetype"`
+}
+
+// ExternalFormats is decoded from JSON array of ExternalFormat objects.
+type ExternalFormats []ExternalFormat
+
+func (f *ExternalFormats) EnvDecode(val string) error {
+	var formats []ExternalFormat
+
+	if strings.TrimSpace(val) == "" {
+		*f = nil
+
+		return nil
+	}
+
+	if err := json.Unmarshal([]byte(val), &formats); err != nil {
+		return fmt.Errorf("unmarshal external formats: %w", err)
+	}
+
+	ids := make(map[int]string, len(formats))
+
+	for i := range formats {
+		format := &formats[i]
+
+		if format.Name == "" {
+			return fmt.Errorf("external format %d: name is required", i)
+		}
+
+		if format.ID < 1 || format.ID > ExternalFormatMaxID {
+			return fmt.Errorf("external format %s: id must be from 1 to %d", format.Name, ExternalFormatMaxID)
+		}
+
+		if name, ok := ids[format.ID]; ok {
+			return fmt.Errorf("external format %s: id %d is used by format %s", format.Name, format.ID, name)
+		}
+
+		ids[format.ID] = format.Name
+
+		if len(format.Command) == 0 {
+			return fmt.Errorf("external format %s: command is required", format.Name)
+		}
+
+		if format.Filename == "" {
+			return fmt.Errorf("external format %s: filename is required", format.Name)
+		}
+
+		switch format.Input {
+		case "":
+			format.Input = ExternalInputURL
+		case ExternalInputURL, ExternalInputHTML:
+		default:
+			return fmt.Errorf("external format %s: invalid input %s", format.Name, format.Input)
+		}
+	}
+
+	*f = formats
+
+	return nil
+}
+
 type API struct {
 	Address string `env:"ADDRESS,default=0.0.0.0:5001"`
 }
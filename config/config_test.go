@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -20,6 +21,15 @@ func TestNewConfig(t *testing.T) {
 		require.NoError(t, err)
 
 		assert.Equal(t, "./db", config.DB.Path)
+		assert.Equal(t, ImageFormat(ImageFormatJPEG), config.Inline.Images.Format)
+		assert.Equal(t, 1024, config.Inline.Images.MaxWidth)
+		assert.Contains(t, config.Client.UserAgent, "Mozilla/5.0 (X11; Linux x86_64)")
+		assert.Equal(t, 30*time.Second, config.Client.Timeout)
+		assert.Equal(t, 4, config.Politeness.HostConcurrency)
+		assert.Equal(t, 30*time.Second, config.Politeness.MaxBackoff)
+		assert.True(t, config.Egress.Enabled)
+		assert.Equal(t, 3, config.Retry.MaxAttempts)
+		assert.Equal(t, 5*time.Second, config.Retry.InitialDelay)
 	})
 
 	t.Run("env without prefix", func(t *testing.T) {
@@ -39,4 +49,47 @@ func TestNewConfig(t *testing.T) {
 
 		assert.Equal(t, "./new_db", config.DB.Path)
 	})
+
+	t.Run("client headers", func(t *testing.T) {
+		require.NoError(t, os.Setenv("CLIENT_HEADERS", "X-Token:secret;Referer:https://example.com/"))
+		t.Cleanup(func() {
+			require.NoError(t, os.Unsetenv("CLIENT_HEADERS"))
+		})
+
+		config, err := NewConfig(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]string{"X-Token": "secret", "Referer": "https://example.com/"}, config.Client.Headers)
+	})
+
+	t.Run("external formats", func(t *testing.T) {
+		require.NoError(t, os.Setenv("EXTERNAL_FORMATS", `[{"id":1,"name":"monolith","command":["monolith","{url}"],"filename":"page.html"}]`))
+		t.Cleanup(func() {
+			require.NoError(t, os.Unsetenv("EXTERNAL_FORMATS"))
+		})
+
+		config, err := NewConfig(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, ExternalFormats{{
+			ID:       1,
+			Name:     "monolith",
+			Command:  []string{"monolith", "{url}"},
+			Input:    ExternalInputURL,
+			Filename: "page.html",
+		}}, config.ExternalFormats)
+
+		for _, formats := range []string{
+			`[{"id":1,"name":"monolith","filename":"page.html"}]`,
+			`[{"name":"monolith","command":["monolith","{url}"],"filename":"page.html"}]`,
+			`[{"id":128,"name":"monolith","command":["monolith","{url}"],"filename":"page.html"}]`,
+			`[{"id":1,"name":"monolith","command":["monolith","{url}"],"filename":"page.html"},` +
+				`{"id":1,"name":"other","command":["other","{url}"],"filename":"page.html"}]`,
+		} {
+			require.NoError(t, os.Setenv("EXTERNAL_FORMATS", formats))
+
+			_, err = NewConfig(ctx)
+			assert.Error(t, err, formats)
+		}
+	})
 }
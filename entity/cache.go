@@ -3,16 +3,54 @@ package entity
 import (
 	"bytes"
 	"io"
+	"maps"
 	"sync"
 )
 
 func NewCache() *Cache {
-	return &Cache{data: make([]byte, 0, 1024*512)}
+	return &Cache{data: make([]byte, 0, 1024*512), resources: make(map[string]CachedResource)}
 }
 
+// NewCacheFromData restores the cache saved with Cache.Data.
+func NewCacheFromData(data CacheData) *Cache {
+	cache := &Cache{
+		data:           data.Document,
+		documentURL:    data.DocumentURL,
+		documentHeader: data.DocumentHeader,
+		resources:      data.Resources,
+	}
+	if cache.resources == nil {
+		cache.resources = make(map[string]CachedResource)
+	}
+
+	return cache
+}
+
+// Cache holds the page document and the resources downloaded by processors, so all formats of the page
+// are made from the same content.
 type Cache struct {
-	mu   sync.RWMutex
-	data []byte
+	mu             sync.RWMutex
+	data           []byte
+	documentURL    string
+	documentHeader map[string][]string
+	resources      map[string]CachedResource
+}
+
+type CachedResource struct {
+	MimeType string
+	// FinalURL is the resource URL after redirects.
+	FinalURL string
+	// Header is the response header, it is empty for the resources cached before it was saved.
+	Header map[string][]string
+	Data   []byte
+}
+
+// CacheData is the cache content for persistence.
+type CacheData struct {
+	Document       []byte
+	DocumentURL    string
+	DocumentHeader map[string][]string
+	Resources      map[string]CachedResource
 }
 
 func (c *Cache) Write(p []byte) (n int, err error) {
@@ -40,3 +78,70 @@ func (c *Cache) Reader() io.Reader {
 
 	return bytes.NewBuffer(c.data)
 }
+
+// SetDocumentResponse saves the response details of the document written with Write.
+func (c *Cache) SetDocumentResponse(finalURL string, header map[string][]string) {
+	c.mu.Lock()
+	c.documentURL = finalURL
+	c.documentHeader = header
+	c.mu.Unlock()
+}
+
+// Document returns the page document with its response details, if it is cached.
+func (c *Cache) Document() (CachedResource, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.document(), len(c.data) > 0
+}
+
+// SetDocument saves the document, unless other one is cached already, and returns the cached document.
+func (c *Cache) SetDocument(document CachedResource) CachedResource {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.data) == 0 {
+		c.data = document.Data
+		c.documentURL = document.FinalURL
+		c.documentHeader = document.Header
+	}
+
+	return c.document()
+}
+
+func (c *Cache) document() CachedResource {
+	document := CachedResource{FinalURL: c.documentURL, Header: c.documentHeader, Data: c.data}
+
+	if contentType := c.documentHeader["Content-Type"]; len(contentType) > 0 {
+		document.MimeType = contentType[0]
+	}
+
+	return document
+}
+
+func (c *Cache) Resource(url string) (CachedResource, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	resource, ok := c.resources[url]
+
+	return resource, ok
+}
+
+func (c *Cache) SetResource(url string, resource CachedResource) {
+	c.mu.Lock()
+	c.resources[url] = resource
+	c.mu.Unlock()
+}
+
+func (c *Cache) Data() CacheData {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return CacheData{
+		Document:       c.data,
+		DocumentURL:    c.documentURL,
+		DocumentHeader: c.documentHeader,
+		Resources:      maps.Clone(c.resources),
+	}
+}
@@ -0,0 +1,136 @@
+package entity
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+)
+
+// FormatExternal is the base value of the formats declared in config, see RegisterExternalFormat.
+const (
+	FormatExternal Format = 128
+	maxFormat      Format = math.MaxUint8
+)
+
+var formatNames = map[Format]string{
+	FormatHeaders:    "headers",
+	FormatSingleFile: "single_file",
+	FormatPDF:        "pdf",
+	FormatWARC:       "warc",
+	FormatMarkdown:   "markdown",
+	FormatHTMLBundle: "html_bundle",
+	FormatScreenshot: "screenshot",
+	FormatText:       "text",
+	FormatEPUB:       "epub",
+	FormatRaw:        "raw",
+}
+
+// documentFormats render the HTML document, for the other content the original file is saved instead,
+// see Page.Process.
+var documentFormats = map[Format]struct{}{
+	FormatSingleFile: {},
+	FormatPDF:        {},
+	FormatMarkdown:   {},
+	FormatHTMLBundle: {},
+	FormatScreenshot: {},
+	FormatText:       {},
+	FormatEPUB:       {},
+}
+
+var externalFormats = struct {
+	mu    sync.RWMutex
+	names map[Format]string
+}{names: make(map[Format]string)}
+
+// Name returns the format name, or empty string for unknown format.
+func (f Format) Name() string {
+	if name, ok := formatNames[f]; ok {
+		return name
+	}
+
+	externalFormats.mu.RLock()
+	defer externalFormats.mu.RUnlock()
+
+	return externalFormats.names[f]
+}
+
+// IsDocument reports whether the format needs the HTML document.
+func (f Format) IsDocument() bool {
+	_, ok := documentFormats[f]
+
+	return ok
+}
+
+// documentFormatsFallback replaces the formats which need the HTML document with FormatRaw.
+func documentFormatsFallback(formats []Format) []Format {
+	result := make([]Format, 0, len(formats))
+
+	for _, format := range formats {
+		if format.IsDocument() {
+			format = FormatRaw
+		}
+
+		if !slices.Contains(result, format) {
+			result = append(result, format)
+		}
+	}
+
+	return result
+}
+
+func ParseFormat(name string) (Format, error) {
+	for format, formatName := range formatNames {
+		if formatName == name {
+			return format, nil
+		}
+	}
+
+	externalFormats.mu.RLock()
+	defer externalFormats.mu.RUnlock()
+
+	for format, formatName := range externalFormats.names {
+		if formatName == name {
+			return format, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown format %s", name)
+}
+
+// RegisterExternalFormat registers the format declared in config. The format value is made of the id set in
+// config, so it stays the same for the stored pages when the formats in config are reordered, renamed or removed.
+func RegisterExternalFormat(id int, name string) (Format, error) {
+	if name == "" {
+		return 0, fmt.Errorf("empty format name")
+	}
+
+	if id < 1 || id > int(maxFormat-FormatExternal) {
+		return 0, fmt.Errorf("format %s id %d is out of range from 1 to %d", name, id, maxFormat-FormatExternal)
+	}
+
+	for _, formatName := range formatNames {
+		if formatName == name {
+			return 0, fmt.Errorf("format name %s is reserved for builtin format", name)
+		}
+	}
+
+	format := FormatExternal + Format(id)
+
+	externalFormats.mu.Lock()
+	defer externalFormats.mu.Unlock()
+
+	if registered, ok := externalFormats.names[format]; ok && registered != name {
+		return 0, fmt.Errorf("format %s id %d is used by format %s", name, id, registered)
+	}
+
+	for registered, registeredName := range externalFormats.names {
+		if registeredName == name && registered != format {
+			return 0, fmt.Errorf("format %s is registered with id %d", name, registered-FormatExternal)
+		}
+	}
+
+	externalFormats.names[format] = name
+
+	return format, nil
+}
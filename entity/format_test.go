@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterExternalFormat(t *testing.T) {
+	t.Parallel()
+
+	format, err := RegisterExternalFormat(1, "monolith")
+	require.NoError(t, err)
+	assert.Equal(t, FormatExternal+1, format)
+	assert.Equal(t, "monolith", format.Name())
+
+	again, err := RegisterExternalFormat(1, "monolith")
+	require.NoError(t, err)
+	assert.Equal(t, format, again)
+
+	_, err = RegisterExternalFormat(1, "other")
+	assert.Error(t, err)
+
+	_, err = RegisterExternalFormat(2, "monolith")
+	assert.Error(t, err)
+
+	last, err := RegisterExternalFormat(127, "last")
+	require.NoError(t, err)
+	assert.Equal(t, Format(255), last)
+
+	_, err = RegisterExternalFormat(0, "zero")
+	assert.Error(t, err)
+
+	_, err = RegisterExternalFormat(128, "overflow")
+	assert.Error(t, err)
+
+	parsed, err := ParseFormat("monolith")
+	require.NoError(t, err)
+	assert.Equal(t, format, parsed)
+
+	_, err = RegisterExternalFormat(3, "pdf")
+	assert.Error(t, err)
+
+	parsed, err = ParseFormat("pdf")
+	require.NoError(t, err)
+	assert.Equal(t, FormatPDF, parsed)
+
+	_, err = ParseFormat("unknown")
+	assert.Error(t, err)
+}
+
+func TestDocumentFormatsFallback(t *testing.T) {
+	t.Parallel()
+
+	formats := documentFormatsFallback([]Format{FormatHeaders, FormatPDF, FormatSingleFile, FormatWARC, FormatRaw})
+	assert.Equal(t, []Format{FormatHeaders, FormatRaw, FormatWARC}, formats)
+}
+
+func TestIsHTMLContentType(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsHTMLContentType("text/html; charset=utf-8"))
+	assert.True(t, IsHTMLContentType("Application/XHTML+XML"))
+	assert.False(t, IsHTMLContentType("application/pdf"))
+	assert.False(t, IsHTMLContentType(""))
+	assert.True(t, Meta{}.IsHTML())
+	assert.False(t, Meta{ContentType: "image/png"}.IsHTML())
+}
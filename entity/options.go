@@ -0,0 +1,29 @@
+package entity
+
+// PageOptions are the page capture settings set on page creation, they override the service config.
+type PageOptions struct {
+	PDF        PDFOptions
+	SingleFile SingleFileOptions
+}
+
+// PDFOptions overrides the config.PDF values. Nil or zero fields mean the config value is used.
+type PDFOptions struct {
+	Landscape *bool
+	Grayscale *bool
+	Zoom      float64
+	Viewport  string
+	PageSize  string
+	MediaType MediaType
+}
+
+type MediaType string
+
+const (
+	MediaTypePrint  MediaType = "print"
+	MediaTypeScreen MediaType = "screen"
+)
+
+// SingleFileOptions overrides the config.SingleFile values. Nil fields mean the config value is used.
+type SingleFileOptions struct {
+	Static *bool
+}
@@ -2,16 +2,21 @@ package entity
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"runtime/debug"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// ErrEgressDenied is returned for the requests to the hosts and addresses denied by the egress policy.
+var ErrEgressDenied = errors.New("denied by egress policy")
+
 type Processor interface {
-	Process(ctx context.Context, format Format, url string, cache *Cache) Result
+	Process(ctx context.Context, format Format, page *PageBase, cache *Cache) Result
 	GetMeta(ctx context.Context, url string, cache *Cache) (Meta, error)
 }
 
@@ -21,12 +26,29 @@ const (
 	FormatHeaders Format = iota
 	FormatSingleFile
 	FormatPDF
+	FormatWARC
+	FormatMarkdown
+	FormatHTMLBundle
+	FormatScreenshot
+	FormatText
+	FormatEPUB
+	FormatRaw
 )
 
+// ThumbnailFilename is the name of the page preview image file of the FormatScreenshot result.
+const ThumbnailFilename = "thumbnail.jpg"
+
 var AllFormats = []Format{
 	FormatHeaders,
 	FormatPDF,
 	FormatSingleFile,
+	FormatWARC,
+	FormatMarkdown,
+	FormatHTMLBundle,
+	FormatScreenshot,
+	FormatText,
+	FormatEPUB,
+	FormatRaw,
 }
 
 type Status uint8
@@ -44,6 +66,35 @@ type Meta struct {
 	Description string
 	Encoding    string
 	Error       string
+	// EgressDenied is set when the egress policy doesn't allow the page URL, no formats are made for such page.
+	EgressDenied bool
+	// ContentType is the page media type without parameters, empty for the pages stored before it was added.
+	ContentType string
+	// Canonical, Favicon and Image are absolute URLs.
+	Canonical string
+	Author    string
+	SiteName  string
+	Language  string
+	Favicon   string
+	Image     string
+	Published time.Time
+	Modified  time.Time
+	// OpenGraph and Twitter are all og:* and twitter:* meta values, keyed by property name without prefix.
+	OpenGraph map[string]string
+	Twitter   map[string]string
+}
+
+// IsHTML reports whether the page is HTML document, other content is saved as is.
+func (m Meta) IsHTML() bool {
+	return m.ContentType == "" || IsHTMLContentType(m.ContentType)
+}
+
+// IsHTMLContentType reports whether the Content-Type value is HTML document type.
+func IsHTMLContentType(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+
+	return mediaType == "text/html" || mediaType == "application/xhtml+xml"
 }
 
 type PageBase struct {
@@ -55,6 +106,7 @@ type PageBase struct {
 	Version     uint16
 	Status      Status
 	Meta        Meta
+	Options     PageOptions
 }
 
 func NewPage(url string, description string, formats ...Format) *Page {
@@ -77,6 +129,37 @@ type Page struct {
 	cache   *Cache
 }
 
+// Thumbnail returns the page preview image file, if the screenshot was made.
+func (p *Page) Thumbnail() (File, bool) {
+	for _, result := range p.Results {
+		if result.Format != FormatScreenshot {
+			continue
+		}
+
+		for _, file := range result.Files {
+			if file.Name == ThumbnailFilename {
+				return file, true
+			}
+		}
+	}
+
+	return File{}, false
+}
+
+// Cache returns the page content cache, it is nil for the page restored from the storage until SetCache call.
+func (p *Page) Cache() *Cache {
+	return p.cache
+}
+
+func (p *Page) SetCache(cache *Cache) {
+	p.cache = cache
+}
+
+// Finished returns true when the page processing is completed, successfully or not.
+func (p *Page) Finished() bool {
+	return p.Status == StatusDone || p.Status == StatusFailed || p.Status == StatusWithErrors
+}
+
 func (p *Page) SetProcessing() {
 	p.Status = StatusProcessing
 }
@@ -85,18 +168,35 @@ func (p *Page) Prepare(ctx context.Context, processor Processor) {
 	meta, err := processor.GetMeta(ctx, p.URL, p.cache)
 	if err != nil {
 		p.Meta.Error = err.Error()
+		p.Meta.EgressDenied = errors.Is(err, ErrEgressDenied)
 	} else {
 		p.Meta = meta
 	}
 }
 
-func (p *Page) Process(ctx context.Context, processor Processor) {
+// Process makes the page formats, the formats failed with transient errors are retried according to the retry policy.
+func (p *Page) Process(ctx context.Context, processor Processor, retry RetryPolicy) {
+	if p.cache == nil {
+		p.cache = NewCache()
+	}
+
+	if p.Meta.EgressDenied {
+		p.deny()
+
+		return
+	}
+
+	formats := p.Formats
+	if !p.Meta.IsHTML() {
+		formats = documentFormatsFallback(formats)
+	}
+
 	innerWG := sync.WaitGroup{}
-	innerWG.Add(len(p.Formats))
+	innerWG.Add(len(formats))
 
 	results := Results{}
 
-	for _, format := range p.Formats {
+	for _, format := range formats {
 		go func(format Format) {
 			defer innerWG.Done()
 
@@ -106,7 +206,9 @@ func (p *Page) Process(ctx context.Context, processor Processor) {
 				}
 			}()
 
-			result := processor.Process(ctx, format, p.URL, p.cache)
+			result := retry.process(ctx, func() Result {
+				return processor.Process(ctx, format, &p.PageBase, p.cache)
+			})
 			results.Add(result)
 		}(format)
 	}
@@ -132,3 +234,15 @@ func (p *Page) Process(ctx context.Context, processor Processor) {
 
 	p.Results = results.RO()
 }
+
+// deny fails all formats of the page which URL is not allowed by the egress policy.
+func (p *Page) deny() {
+	results := make([]Result, len(p.Formats))
+
+	for i, format := range p.Formats {
+		results[i] = Result{Format: format, Err: errors.New(p.Meta.Error)}
+	}
+
+	p.Status = StatusFailed
+	p.Results = results
+}
@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type deniedProcessor struct {
+	processed int
+}
+
+func (p *deniedProcessor) Process(_ context.Context, format Format, _ *PageBase, _ *Cache) Result {
+	p.processed++
+
+	return Result{Format: format}
+}
+
+func (p *deniedProcessor) GetMeta(_ context.Context, url string, _ *Cache) (Meta, error) {
+	return Meta{}, fmt.Errorf("do request: host %s: %w", url, ErrEgressDenied)
+}
+
+func TestPage_ProcessEgressDenied(t *testing.T) {
+	t.Parallel()
+
+	processor := &deniedProcessor{}
+
+	page := NewPage("http://169.254.169.254/", "", FormatRaw, FormatPDF)
+	page.Prepare(context.Background(), processor)
+
+	require.True(t, page.Meta.EgressDenied)
+
+	page.Process(context.Background(), processor, RetryPolicy{})
+
+	assert.Zero(t, processor.processed)
+	assert.Equal(t, StatusFailed, page.Status)
+	require.Len(t, page.Results, 2)
+	assert.ErrorContains(t, page.Results[0].Err, ErrEgressDenied.Error())
+}
@@ -0,0 +1,177 @@
+package entity
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const netscapeHTTPOnlyPrefix = "#HttpOnly_"
+
+func NewProfile(name string, domain string) *Profile {
+	return &Profile{
+		ID:      uuid.New(),
+		Name:    name,
+		Domain:  strings.ToLower(strings.TrimSpace(domain)),
+		Created: time.Now(),
+	}
+}
+
+// Profile holds the credentials applied to the requests to the matching hosts, so the pages available
+// for the authenticated users only can be saved.
+type Profile struct {
+	ID   uuid.UUID
+	Name string
+	// Domain matches the host and its subdomains, domain like *.example.com matches the subdomains only.
+	Domain   string
+	Cookies  []Cookie
+	Headers  map[string]string
+	Username string
+	Password string
+	Created  time.Time
+}
+
+func (p *Profile) Validate() error {
+	domain := strings.TrimPrefix(p.Domain, "*.")
+
+	if domain == "" || strings.ContainsAny(domain, "*/:@ ") {
+		return fmt.Errorf("invalid domain %q", p.Domain)
+	}
+
+	return nil
+}
+
+// Matches reports whether the profile is applied to the host requests.
+func (p *Profile) Matches(host string) bool {
+	host = strings.ToLower(host)
+
+	if domain, ok := strings.CutPrefix(p.Domain, "*."); ok {
+		return strings.HasSuffix(host, "."+domain)
+	}
+
+	return host == p.Domain || strings.HasSuffix(host, "."+p.Domain)
+}
+
+// MatchProfile returns the profile with the most specific domain matching the host, or nil.
+func MatchProfile(profiles []*Profile, host string) *Profile {
+	var matched *Profile
+
+	for _, profile := range profiles {
+		if profile.Matches(host) && (matched == nil || len(profile.Domain) > len(matched.Domain)) {
+			matched = profile
+		}
+	}
+
+	return matched
+}
+
+// Cookie is the profile cookie, as stored in the Netscape cookies.txt file.
+type Cookie struct {
+	Name   string
+	Value  string
+	Domain string
+	// IncludeSubdomains makes the cookie sent to the Domain subdomains too.
+	IncludeSubdomains bool
+	Path              string
+	Secure            bool
+	HTTPOnly          bool
+	// Expires is zero for the session cookies.
+	Expires time.Time
+}
+
+// Matches reports whether the cookie is sent with the request to the URL.
+func (c *Cookie) Matches(u *url.URL, now time.Time) bool {
+	if !c.Expires.IsZero() && c.Expires.Before(now) {
+		return false
+	}
+
+	if c.Secure && u.Scheme != "https" {
+		return false
+	}
+
+	host := strings.ToLower(u.Hostname())
+	domain := strings.ToLower(strings.TrimPrefix(c.Domain, "."))
+
+	if host != domain && !(c.IncludeSubdomains && strings.HasSuffix(host, "."+domain)) {
+		return false
+	}
+
+	requestPath := u.EscapedPath()
+	if requestPath == "" {
+		requestPath = "/"
+	}
+
+	cookiePath := c.Path
+	if cookiePath == "" {
+		cookiePath = "/"
+	}
+
+	return requestPath == cookiePath ||
+		strings.HasPrefix(requestPath, cookiePath) &&
+			(strings.HasSuffix(cookiePath, "/") || requestPath[len(cookiePath)] == '/')
+}
+
+// ParseNetscapeCookies reads the cookies.txt file exported by browsers and curl. Each line has tab separated
+// domain, include subdomains flag, path, secure flag, expiration unix time, name and value.
+func ParseNetscapeCookies(reader io.Reader) ([]Cookie, error) {
+	var cookies []Cookie
+
+	scanner := bufio.NewScanner(reader)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		httpOnly := strings.HasPrefix(line, netscapeHTTPOnlyPrefix)
+		line = strings.TrimPrefix(line, netscapeHTTPOnlyPrefix)
+
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) == 6 {
+			// Cookies with empty value may have no trailing tab.
+			fields = append(fields, "")
+		}
+
+		if len(fields) != 7 {
+			return nil, fmt.Errorf("line %d: want 7 tab separated fields, got %d", lineNumber, len(fields))
+		}
+
+		expires, err := strconv.ParseInt(fields[4], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: parse expiration time: %w", lineNumber, err)
+		}
+
+		cookie := Cookie{
+			Name:              fields[5],
+			Value:             fields[6],
+			Domain:            fields[0],
+			IncludeSubdomains: strings.EqualFold(fields[1], "TRUE"),
+			Path:              fields[2],
+			Secure:            strings.EqualFold(fields[3], "TRUE"),
+			HTTPOnly:          httpOnly,
+		}
+
+		if expires > 0 {
+			cookie.Expires = time.Unix(expires, 0).UTC()
+		}
+
+		cookies = append(cookies, cookie)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read cookies: %w", err)
+	}
+
+	return cookies, nil
+}
@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNetscapeCookies(t *testing.T) {
+	t.Parallel()
+
+	cookies, err := ParseNetscapeCookies(strings.NewReader("# Netscape HTTP Cookie File\n" +
+		"\n" +
+		".example.com\tTRUE\t/\tTRUE\t1893456000\tsession\tsecret\n" +
+		"#HttpOnly_intranet.local\tFALSE\t/app\tFALSE\t0\ttoken\tabc=\r\n" +
+		"example.com\tFALSE\t/\tFALSE\t0\tempty\n"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []Cookie{
+		{
+			Name: "session", Value: "secret", Domain: ".example.com", IncludeSubdomains: true, Path: "/",
+			Secure: true, Expires: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{Name: "token", Value: "abc=", Domain: "intranet.local", Path: "/app", HTTPOnly: true},
+		{Name: "empty", Domain: "example.com", Path: "/"},
+	}, cookies)
+
+	_, err = ParseNetscapeCookies(strings.NewReader("example.com\tFALSE\t/\n"))
+	assert.ErrorContains(t, err, "line 1")
+}
+
+func TestCookie_Matches(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	cookie := Cookie{Name: "a", Domain: ".example.com", IncludeSubdomains: true, Path: "/app", Secure: true}
+
+	parse := func(rawURL string) *url.URL {
+		parsed, err := url.Parse(rawURL)
+		require.NoError(t, err)
+
+		return parsed
+	}
+
+	assert.True(t, cookie.Matches(parse("https://example.com/app"), now))
+	assert.True(t, cookie.Matches(parse("https://www.example.com/app/page"), now))
+	assert.False(t, cookie.Matches(parse("http://example.com/app"), now), "secure")
+	assert.False(t, cookie.Matches(parse("https://example.com/application"), now), "path")
+	assert.False(t, cookie.Matches(parse("https://notexample.com/app"), now), "domain")
+
+	cookie.Expires = now.Add(-time.Minute)
+	assert.False(t, cookie.Matches(parse("https://example.com/app"), now), "expired")
+}
+
+func TestMatchProfile(t *testing.T) {
+	t.Parallel()
+
+	site := NewProfile("site", "Example.com")
+	subdomains := NewProfile("subdomains", "*.example.com")
+	intranet := NewProfile("intranet", "intranet.example.com")
+	profiles := []*Profile{site, subdomains, intranet}
+
+	assert.Equal(t, site, MatchProfile(profiles, "example.com"))
+	assert.Equal(t, subdomains, MatchProfile(profiles, "www.example.com"))
+	assert.Equal(t, intranet, MatchProfile(profiles, "wiki.intranet.example.com"))
+	assert.Nil(t, MatchProfile(profiles, "example.org"))
+
+	assert.NoError(t, subdomains.Validate())
+	assert.Error(t, NewProfile("invalid", "https://example.com").Validate())
+}
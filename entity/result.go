@@ -2,6 +2,7 @@ package entity
 
 import (
 	"sync"
+	"time"
 
 	"github.com/vmihailenco/msgpack/v5"
 )
@@ -12,6 +13,14 @@ type Result struct {
 	Format Format
 	Err    error
 	Files  []File
+	// Attempts are the format processing attempts, the last one made the result.
+	Attempts []Attempt
+}
+
+// Attempt is the format processing attempt, Err is empty for the successful one.
+type Attempt struct {
+	Started time.Time
+	Err     string
 }
 
 type Results struct {
@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// TransientError marks the processing error which may disappear on retry, like timeout or 5xx response.
+type TransientError struct {
+	Err error
+}
+
+func NewTransientError(err error) error {
+	return &TransientError{Err: err}
+}
+
+func (e *TransientError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *TransientError) Unwrap() error {
+	return e.Err
+}
+
+func IsTransient(err error) bool {
+	var transient *TransientError
+
+	return errors.As(err, &transient)
+}
+
+// RetryPolicy is the repeated processing of the formats failed with transient errors. The zero policy makes
+// the single attempt.
+type RetryPolicy struct {
+	MaxAttempts  int
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+}
+
+// Delay returns the delay before the attempt following the given one, attempts are counted from 1.
+func (p RetryPolicy) Delay(attempt int) time.Duration {
+	delay := p.InitialDelay
+
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+
+		if p.MaxDelay > 0 && delay >= p.MaxDelay {
+			break
+		}
+	}
+
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	return delay
+}
+
+// process runs the processing until it succeeds, fails with not transient error, or attempts are exhausted.
+// Every attempt is recorded in the result.
+func (p RetryPolicy) process(ctx context.Context, process func() Result) Result {
+	var attempts []Attempt
+
+	for attempt := 1; ; attempt++ {
+		started := time.Now()
+		result := process()
+
+		record := Attempt{Started: started}
+		if result.Err != nil {
+			record.Err = result.Err.Error()
+		}
+
+		attempts = append(attempts, record)
+		result.Attempts = attempts
+
+		if result.Err == nil || !IsTransient(result.Err) || attempt >= p.MaxAttempts {
+			return result
+		}
+
+		timer := time.NewTimer(p.Delay(attempt))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return result
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package entity
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicy{MaxAttempts: 10, InitialDelay: time.Second, MaxDelay: 5 * time.Second}
+
+	assert.Equal(t, time.Second, policy.Delay(1))
+	assert.Equal(t, 2*time.Second, policy.Delay(2))
+	assert.Equal(t, 4*time.Second, policy.Delay(3))
+	assert.Equal(t, 5*time.Second, policy.Delay(4))
+	assert.Equal(t, 5*time.Second, policy.Delay(100))
+}
+
+func TestRetryPolicy_process(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	policy := RetryPolicy{MaxAttempts: 3, InitialDelay: time.Millisecond, MaxDelay: time.Millisecond}
+
+	t.Run("transient error", func(t *testing.T) {
+		t.Parallel()
+
+		calls := 0
+		result := policy.process(ctx, func() Result {
+			calls++
+			if calls < 3 {
+				return Result{Format: FormatRaw, Err: NewTransientError(errors.New("timeout"))}
+			}
+
+			return Result{Format: FormatRaw, Files: []File{{Name: "file"}}}
+		})
+
+		require.NoError(t, result.Err)
+		require.Len(t, result.Attempts, 3)
+		assert.Equal(t, "timeout", result.Attempts[0].Err)
+		assert.Equal(t, "timeout", result.Attempts[1].Err)
+		assert.Empty(t, result.Attempts[2].Err)
+		assert.False(t, result.Attempts[0].Started.After(result.Attempts[2].Started))
+	})
+
+	t.Run("attempts exhausted", func(t *testing.T) {
+		t.Parallel()
+
+		calls := 0
+		result := policy.process(ctx, func() Result {
+			calls++
+
+			return Result{Format: FormatRaw, Err: NewTransientError(errors.New("timeout"))}
+		})
+
+		assert.True(t, IsTransient(result.Err))
+		assert.Equal(t, 3, calls)
+		assert.Len(t, result.Attempts, 3)
+	})
+
+	t.Run("permanent error", func(t *testing.T) {
+		t.Parallel()
+
+		calls := 0
+		result := policy.process(ctx, func() Result {
+			calls++
+
+			return Result{Format: FormatRaw, Err: errors.New("not found")}
+		})
+
+		assert.Error(t, result.Err)
+		assert.Equal(t, 1, calls)
+		assert.Len(t, result.Attempts, 1)
+	})
+
+	t.Run("zero policy", func(t *testing.T) {
+		t.Parallel()
+
+		calls := 0
+		result := RetryPolicy{}.process(ctx, func() Result {
+			calls++
+
+			return Result{Format: FormatRaw, Err: NewTransientError(errors.New("timeout"))}
+		})
+
+		assert.Error(t, result.Err)
+		assert.Equal(t, 1, calls)
+	})
+}
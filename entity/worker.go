@@ -3,12 +3,19 @@ package entity
 import (
 	"context"
 	"sync"
+	"time"
 
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
+// cacheSaveInterval is the period of the cache saving while the page is processed, so the resumed processing
+// reuses the already downloaded resources.
+const cacheSaveInterval = 10 * time.Second
+
 type Pages interface {
 	Save(ctx context.Context, page *Page) error
+	SaveCache(ctx context.Context, pageID uuid.UUID, cache *Cache) error
 	ListUnprocessed(ctx context.Context) ([]Page, error)
 }
 
@@ -20,9 +27,17 @@ type Worker struct {
 	ch        chan *Page
 	pages     Pages
 	processor Processor
+	retry     RetryPolicy
 	log       *zap.Logger
 }
 
+// WithRetry enables retries of the formats failed with transient errors.
+func (w *Worker) WithRetry(retry RetryPolicy) *Worker {
+	w.retry = retry
+
+	return w
+}
+
 func (w *Worker) Start(ctx context.Context, wg *sync.WaitGroup) {
 	defer wg.Done()
 
@@ -76,7 +91,13 @@ func (w *Worker) do(ctx context.Context, wg *sync.WaitGroup, page *Page, log *za
 		)
 	}
 
-	page.Process(ctx, w.processor)
+	if page.Cache() == nil {
+		page.SetCache(NewCache())
+	}
+
+	stopCacheSaving := w.saveCache(ctx, page.ID, page.Cache(), log)
+	page.Process(ctx, w.processor, w.retry)
+	stopCacheSaving()
 
 	log.Debug("page processed")
 
@@ -89,3 +110,34 @@ func (w *Worker) do(ctx context.Context, wg *sync.WaitGroup, page *Page, log *za
 		)
 	}
 }
+
+// saveCache saves the page cache periodically until the returned stop function is called.
+func (w *Worker) saveCache(ctx context.Context, pageID uuid.UUID, cache *Cache, log *zap.Logger) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(cacheSaveInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := w.pages.SaveCache(ctx, pageID, cache); err != nil {
+					log.Error("failed to save page cache", zap.Error(err))
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
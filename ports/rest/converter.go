@@ -3,6 +3,9 @@ package rest
 import (
 	"fmt"
 	"html"
+	"maps"
+	"slices"
+	"time"
 
 	"github.com/derfenix/webarchive/api/openapi"
 	"github.com/derfenix/webarchive/entity"
@@ -22,12 +25,10 @@ func PageToRestWithResults(page *entity.Page) openapi.PageWithResults {
 
 			return res
 		}(),
-		Status: StatusToRest(page.Status),
-		Meta: openapi.PageWithResultsMeta{
-			Title:       html.EscapeString(page.Meta.Title),
-			Description: html.EscapeString(page.Meta.Description),
-			Error:       openapi.NewOptString(page.Meta.Error),
-		},
+		Status:    StatusToRest(page.Status),
+		Thumbnail: ThumbnailToRest(page),
+		Options:   OptionsToRest(page.Options),
+		Meta:      MetaToRest(page.Meta),
 		Results: func() []openapi.Result {
 			results := make([]openapi.Result, len(page.Results))
 
@@ -58,6 +59,20 @@ func PageToRestWithResults(page *entity.Page) openapi.PageWithResults {
 
 						return files
 					}(),
+					Attempts: func() []openapi.Attempt {
+						attempts := make([]openapi.Attempt, len(result.Attempts))
+
+						for j := range attempts {
+							attempt := &result.Attempts[j]
+
+							attempts[j] = openapi.Attempt{Started: attempt.Started}
+							if attempt.Err != "" {
+								attempts[j].Error = openapi.NewOptString(attempt.Err)
+							}
+						}
+
+						return attempts
+					}(),
 				}
 			}
 
@@ -71,11 +86,7 @@ func BasePageToRest(page *entity.PageBase) openapi.Page {
 		ID:      page.ID,
 		URL:     page.URL,
 		Created: page.Created,
-		Meta: openapi.PageMeta{
-			Title:       html.EscapeString(page.Meta.Title),
-			Description: html.EscapeString(page.Meta.Description),
-			Error:       openapi.NewOptString(page.Meta.Error),
-		},
+		Meta:    MetaToRest(page.Meta),
 		Formats: func() []openapi.Format {
 			res := make([]openapi.Format, len(page.Formats))
 
@@ -85,7 +96,8 @@ func BasePageToRest(page *entity.PageBase) openapi.Page {
 
 			return res
 		}(),
-		Status: StatusToRest(page.Status),
+		Status:  StatusToRest(page.Status),
+		Options: OptionsToRest(page.Options),
 	}
 }
 
@@ -94,11 +106,7 @@ func PageToRest(page *entity.Page) openapi.Page {
 		ID:      page.ID,
 		URL:     page.URL,
 		Created: page.Created,
-		Meta: openapi.PageMeta{
-			Title:       html.EscapeString(page.Meta.Title),
-			Description: html.EscapeString(page.Meta.Description),
-			Error:       openapi.NewOptString(page.Meta.Error),
-		},
+		Meta:    MetaToRest(page.Meta),
 		Formats: func() []openapi.Format {
 			res := make([]openapi.Format, len(page.Formats))
 
@@ -108,8 +116,130 @@ func PageToRest(page *entity.Page) openapi.Page {
 
 			return res
 		}(),
-		Status: StatusToRest(page.Status),
+		Status:    StatusToRest(page.Status),
+		Thumbnail: ThumbnailToRest(page),
+		Options:   OptionsToRest(page.Options),
+	}
+}
+
+func ThumbnailToRest(page *entity.Page) openapi.OptUUID {
+	thumbnail, ok := page.Thumbnail()
+	if !ok {
+		return openapi.OptUUID{}
+	}
+
+	return openapi.NewOptUUID(thumbnail.ID)
+}
+
+// MetaToRest converts page meta, the title and description are escaped to be shown as html, other values
+// are returned as is.
+func MetaToRest(meta entity.Meta) openapi.PageMeta {
+	optString := func(value string) openapi.OptString {
+		if value == "" {
+			return openapi.OptString{}
+		}
+
+		return openapi.NewOptString(value)
+	}
+
+	optTime := func(value time.Time) openapi.OptDateTime {
+		if value.IsZero() {
+			return openapi.OptDateTime{}
+		}
+
+		return openapi.NewOptDateTime(value)
+	}
+
+	res := openapi.PageMeta{
+		Title:       html.EscapeString(meta.Title),
+		Description: html.EscapeString(meta.Description),
+		Error:       openapi.NewOptString(meta.Error),
+		ContentType: optString(meta.ContentType),
+		Canonical:   optString(meta.Canonical),
+		Author:      optString(meta.Author),
+		SiteName:    optString(meta.SiteName),
+		Language:    optString(meta.Language),
+		Favicon:     optString(meta.Favicon),
+		Image:       optString(meta.Image),
+		Published:   optTime(meta.Published),
+		Modified:    optTime(meta.Modified),
+	}
+
+	if len(meta.OpenGraph) > 0 {
+		res.Opengraph = openapi.NewOptPageMetaOpengraph(meta.OpenGraph)
+	}
+
+	if len(meta.Twitter) > 0 {
+		res.Twitter = openapi.NewOptPageMetaTwitter(meta.Twitter)
+	}
+
+	return res
+}
+
+func OptionsFromRest(options openapi.OptPageOptions) entity.PageOptions {
+	pdf := options.Value.Pdf.Value
+
+	res := entity.PageOptions{
+		PDF: entity.PDFOptions{
+			Zoom:      pdf.Zoom.Value,
+			Viewport:  pdf.Viewport.Value,
+			PageSize:  string(pdf.PageSize.Value),
+			MediaType: entity.MediaType(pdf.MediaType.Value),
+		},
+	}
+
+	if pdf.Landscape.IsSet() {
+		res.PDF.Landscape = &pdf.Landscape.Value
+	}
+
+	if pdf.Grayscale.IsSet() {
+		res.PDF.Grayscale = &pdf.Grayscale.Value
+	}
+
+	if singleFile := options.Value.SingleFile.Value; singleFile.Static.IsSet() {
+		res.SingleFile.Static = &singleFile.Static.Value
+	}
+
+	return res
+}
+
+func OptionsToRest(options entity.PageOptions) openapi.OptPageOptions {
+	pdf := openapi.PdfOptions{}
+
+	if options.PDF.Landscape != nil {
+		pdf.Landscape = openapi.NewOptBool(*options.PDF.Landscape)
+	}
+
+	if options.PDF.Grayscale != nil {
+		pdf.Grayscale = openapi.NewOptBool(*options.PDF.Grayscale)
+	}
+
+	if options.PDF.Zoom != 0 {
+		pdf.Zoom = openapi.NewOptFloat64(options.PDF.Zoom)
 	}
+
+	if options.PDF.Viewport != "" {
+		pdf.Viewport = openapi.NewOptString(options.PDF.Viewport)
+	}
+
+	if options.PDF.PageSize != "" {
+		pdf.PageSize = openapi.NewOptPdfOptionsPageSize(openapi.PdfOptionsPageSize(options.PDF.PageSize))
+	}
+
+	if options.PDF.MediaType != "" {
+		pdf.MediaType = openapi.NewOptPdfOptionsMediaType(openapi.PdfOptionsMediaType(options.PDF.MediaType))
+	}
+
+	singleFile := openapi.SingleFileOptions{}
+
+	if options.SingleFile.Static != nil {
+		singleFile.Static = openapi.NewOptBool(*options.SingleFile.Static)
+	}
+
+	return openapi.NewOptPageOptions(openapi.PageOptions{
+		Pdf:        openapi.NewOptPdfOptions(pdf),
+		SingleFile: openapi.NewOptSingleFileOptions(singleFile),
+	})
 }
 
 func StatusToRest(s entity.Status) openapi.Status {
@@ -129,29 +259,25 @@ func StatusToRest(s entity.Status) openapi.Status {
 	}
 }
 
+// FormatAll is the pseudo-format meaning all builtin formats.
+const FormatAll openapi.Format = "all"
+
 func FormatFromRest(format []openapi.Format) ([]entity.Format, error) {
 	var formats []entity.Format
 
 	switch {
-	case len(format) == 0 || (len(format) == 1 && format[0] == openapi.FormatAll):
+	case len(format) == 0 || (len(format) == 1 && format[0] == FormatAll):
 		formats = entity.AllFormats
 
 	default:
 		formats = make([]entity.Format, len(format))
 		for i, format := range format {
-			switch format {
-			case openapi.FormatPdf:
-				formats[i] = entity.FormatPDF
-
-			case openapi.FormatHeaders:
-				formats[i] = entity.FormatHeaders
-
-			case openapi.FormatSingleFile:
-				formats[i] = entity.FormatSingleFile
-
-			default:
+			domainFormat, err := entity.ParseFormat(string(format))
+			if err != nil {
 				return nil, fmt.Errorf("invalid format value %s", format)
 			}
+
+			formats[i] = domainFormat
 		}
 	}
 
@@ -159,14 +285,39 @@ func FormatFromRest(format []openapi.Format) ([]entity.Format, error) {
 }
 
 func FormatToRest(format entity.Format) openapi.Format {
-	switch format {
-	case entity.FormatPDF:
-		return openapi.FormatPdf
-	case entity.FormatSingleFile:
-		return openapi.FormatSingleFile
-	case entity.FormatHeaders:
-		return openapi.FormatHeaders
-	default:
-		return ""
+	return openapi.Format(format.Name())
+}
+
+// ProfileToRest converts the profile without its secrets: header values, cookie values and password.
+func ProfileToRest(profile *entity.Profile) openapi.Profile {
+	res := openapi.Profile{
+		ID:      profile.ID,
+		Name:    profile.Name,
+		Domain:  profile.Domain,
+		Created: profile.Created,
+		Headers: slices.Sorted(maps.Keys(profile.Headers)),
+		Cookies: make([]openapi.ProfileCookiesItem, len(profile.Cookies)),
 	}
+
+	if profile.Username != "" {
+		res.Username = openapi.NewOptString(profile.Username)
+	}
+
+	if res.Headers == nil {
+		res.Headers = []string{}
+	}
+
+	for i, cookie := range profile.Cookies {
+		res.Cookies[i] = openapi.ProfileCookiesItem{
+			Name:   cookie.Name,
+			Domain: cookie.Domain,
+			Path:   cookie.Path,
+		}
+
+		if !cookie.Expires.IsZero() {
+			res.Cookies[i].Expires = openapi.NewOptDateTime(cookie.Expires)
+		}
+	}
+
+	return res
 }
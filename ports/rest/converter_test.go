@@ -0,0 +1,68 @@
+package rest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/derfenix/webarchive/api/openapi"
+	"github.com/derfenix/webarchive/entity"
+)
+
+func TestOptionsRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	yes, no := true, false
+
+	for name, options := range map[string]entity.PageOptions{
+		"empty": {},
+		"all set": {
+			PDF: entity.PDFOptions{
+				Landscape: &yes,
+				Grayscale: &yes,
+				Zoom:      1.5,
+				Viewport:  "1920x1080",
+				PageSize:  "A3",
+				MediaType: entity.MediaTypeScreen,
+			},
+			SingleFile: entity.SingleFileOptions{Static: &yes},
+		},
+		"false values": {
+			PDF:        entity.PDFOptions{Landscape: &no, Grayscale: &no},
+			SingleFile: entity.SingleFileOptions{Static: &no},
+		},
+	} {
+		assert.Equal(t, options, OptionsFromRest(OptionsToRest(options)), name)
+	}
+}
+
+func TestOptionsFromRest(t *testing.T) {
+	t.Parallel()
+
+	unset := OptionsFromRest(openapi.OptPageOptions{})
+	assert.Nil(t, unset.PDF.Landscape)
+	assert.Nil(t, unset.PDF.Grayscale)
+	assert.Nil(t, unset.SingleFile.Static)
+
+	options := OptionsFromRest(openapi.NewOptPageOptions(openapi.PageOptions{
+		Pdf: openapi.NewOptPdfOptions(openapi.PdfOptions{
+			Landscape: openapi.NewOptBool(false),
+			PageSize:  openapi.NewOptPdfOptionsPageSize(openapi.PdfOptionsPageSizeLetter),
+			MediaType: openapi.NewOptPdfOptionsMediaType(openapi.PdfOptionsMediaTypePrint),
+		}),
+	}))
+
+	if assert.NotNil(t, options.PDF.Landscape) {
+		assert.False(t, *options.PDF.Landscape)
+	}
+
+	assert.Nil(t, options.PDF.Grayscale)
+	assert.Equal(t, "Letter", options.PDF.PageSize)
+	assert.Equal(t, entity.MediaTypePrint, options.PDF.MediaType)
+
+	rest := OptionsToRest(options).Value.Pdf.Value
+	assert.True(t, rest.Landscape.IsSet())
+	assert.False(t, rest.Grayscale.IsSet())
+	assert.False(t, rest.Zoom.IsSet())
+	assert.False(t, rest.Viewport.IsSet())
+}
@@ -3,10 +3,12 @@ package rest
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 
+	"github.com/dgraph-io/badger/v4"
 	"github.com/google/uuid"
 
 	"github.com/derfenix/webarchive/api/openapi"
@@ -20,9 +22,16 @@ type Pages interface {
 	GetFile(ctx context.Context, pageID, fileID uuid.UUID) (*entity.File, error)
 }
 
-func NewService(pages Pages, ch chan *entity.Page, processor entity.Processor) *Service {
+type Profiles interface {
+	ListAll(ctx context.Context) ([]*entity.Profile, error)
+	Save(ctx context.Context, profile *entity.Profile) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+func NewService(pages Pages, profiles Profiles, ch chan *entity.Page, processor entity.Processor) *Service {
 	return &Service{
 		pages:     pages,
+		profiles:  profiles,
 		ch:        ch,
 		processor: processor,
 	}
@@ -32,6 +41,7 @@ type Service struct {
 	openapi.UnimplementedHandler
 	processor entity.Processor
 	pages     Pages
+	profiles  Profiles
 	ch        chan *entity.Page
 }
 
@@ -55,7 +65,7 @@ func (s *Service) AddPage(ctx context.Context, req openapi.OptAddPageReq, params
 		formats = params.Formats
 	}
 	if len(formats) == 0 {
-		formats = []openapi.Format{"all"}
+		formats = []openapi.Format{FormatAll}
 	}
 
 	switch {
@@ -82,6 +92,7 @@ func (s *Service) AddPage(ctx context.Context, req openapi.OptAddPageReq, params
 
 	page := entity.NewPage(url, description, domainFormats...)
 	page.Status = entity.StatusNew
+	page.Options = OptionsFromRest(req.Value.Options)
 	page.Prepare(ctx, s.processor)
 
 	if err := s.pages.Save(ctx, page); err != nil {
@@ -125,9 +136,84 @@ func (s *Service) GetFile(ctx context.Context, params openapi.GetFileParams) (op
 	case strings.HasPrefix(file.MimeType, "text/html"):
 		return &openapi.GetFileOKTextHTML{Data: bytes.NewReader(file.Data)}, nil
 
+	case file.MimeType == "application/warc":
+		return &openapi.GetFileOKApplicationWarc{Data: bytes.NewReader(file.Data)}, nil
+
+	case file.MimeType == "application/zip":
+		return &openapi.GetFileOKApplicationZip{Data: bytes.NewReader(file.Data)}, nil
+
+	case file.MimeType == "image/png":
+		return &openapi.GetFileOKImagePNG{Data: bytes.NewReader(file.Data)}, nil
+
+	case file.MimeType == "image/jpeg":
+		return &openapi.GetFileOKImageJpeg{Data: bytes.NewReader(file.Data)}, nil
+
+	case file.MimeType == "application/epub+zip":
+		return &openapi.GetFileOKApplicationEpubZip{Data: bytes.NewReader(file.Data)}, nil
+
 	default:
-		return nil, fmt.Errorf("unsupported mimetype: %s", file.MimeType)
+		return &openapi.GetFileOKApplicationOctetStream{Data: bytes.NewReader(file.Data)}, nil
+	}
+}
+
+func (s *Service) GetProfiles(ctx context.Context) ([]openapi.Profile, error) {
+	profiles, err := s.profiles.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list all: %w", err)
+	}
+
+	res := make([]openapi.Profile, len(profiles))
+	for i := range res {
+		res[i] = ProfileToRest(profiles[i])
+	}
+
+	return res, nil
+}
+
+func (s *Service) AddProfile(ctx context.Context, req *openapi.AddProfileReq) (openapi.AddProfileRes, error) {
+	profile := entity.NewProfile(req.Name, req.Domain)
+	profile.Headers = req.Headers.Value
+	profile.Username = req.Username.Value
+	profile.Password = req.Password.Value
+
+	if err := profile.Validate(); err != nil {
+		return &openapi.AddProfileBadRequest{
+			Field: "domain",
+			Error: err.Error(),
+		}, nil
+	}
+
+	if req.Cookies.Value != "" {
+		cookies, err := entity.ParseNetscapeCookies(strings.NewReader(req.Cookies.Value))
+		if err != nil {
+			return &openapi.AddProfileBadRequest{
+				Field: "cookies",
+				Error: err.Error(),
+			}, nil
+		}
+
+		profile.Cookies = cookies
+	}
+
+	if err := s.profiles.Save(ctx, profile); err != nil {
+		return nil, fmt.Errorf("save profile: %w", err)
 	}
+
+	res := ProfileToRest(profile)
+
+	return &res, nil
+}
+
+func (s *Service) DeleteProfile(ctx context.Context, params openapi.DeleteProfileParams) (openapi.DeleteProfileRes, error) {
+	if err := s.profiles.Delete(ctx, params.ID); err != nil {
+		if errors.Is(err, badger.ErrKeyNotFound) {
+			return &openapi.DeleteProfileNotFound{}, nil
+		}
+
+		return nil, fmt.Errorf("delete profile: %w", err)
+	}
+
+	return &openapi.DeleteProfileNoContent{}, nil
 }
 
 func (s *Service) NewError(_ context.Context, err error) *openapi.ErrorStatusCode {
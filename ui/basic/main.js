@@ -19,6 +19,11 @@ function index() {
         $(page_elem).find(".created").html(v.created);
         $(page_elem).find(".title").html(v.meta.title);
         $(page_elem).find(".description").html(v.meta.description);
+        if (v.thumbnail !== undefined) {
+          $(page_elem).find(".thumbnail").attr("src", "/api/v1/pages/" + v.id + "/file/" + v.thumbnail);
+        } else {
+          $(page_elem).find(".thumbnail").remove();
+        }
         elem.append(page_elem); // (*)
       })
     }